@@ -0,0 +1,359 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mrjxtr/rpug/internal/data"
+)
+
+const commandsUsage = `usage: rpug [command]
+
+With no command, rpug starts the API server.
+
+commands:
+  data import-locations  rebuild data.json locations from a locations CSV
+  data lint              validate the embedded data, plus any files given
+  data freeze            copy data.json into data/versions and bump its version
+`
+
+// runCommand dispatches `rpug <group> <command> [flags]` and returns the exit code.
+func runCommand(args []string) int {
+	if len(args) < 2 || args[0] != "data" {
+		fmt.Fprint(os.Stderr, commandsUsage)
+		return 2
+	}
+
+	switch args[1] {
+	case "import-locations":
+		return runImportLocations(args[2:])
+	case "lint":
+		return runLint(args[2:])
+	case "freeze":
+		return runFreeze(args[2:])
+	default:
+		fmt.Fprint(os.Stderr, commandsUsage)
+		return 2
+	}
+}
+
+// runImportLocations replaces the locations in a data.json with the
+// hierarchy parsed from a locations CSV, leaving every other section, and
+// the religions, ethnicities and languages of existing regions, untouched.
+func runImportLocations(args []string) int {
+	fs := flag.NewFlagSet("import-locations", flag.ContinueOnError)
+	csvPath := fs.String("csv", "data/locations.csv", "locations CSV to import")
+	dataPath := fs.String("data", "data/data.json", "data.json to update in place")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if err := importLocations(*csvPath, *dataPath); err != nil {
+		fmt.Fprintln(os.Stderr, "import-locations:", err)
+		return 1
+	}
+
+	fmt.Printf("Imported %s into %s\n", *csvPath, *dataPath)
+	return 0
+}
+
+// importLocations does the work for runImportLocations.
+func importLocations(csvPath, dataPath string) error {
+	raw, err := os.ReadFile(dataPath)
+	if err != nil {
+		return err
+	}
+	var d data.Data
+	if err := json.Unmarshal(raw, &d); err != nil {
+		return fmt.Errorf("%s: %w", dataPath, err)
+	}
+
+	f, err := os.Open(csvPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	locations, err := data.ParseLocations(f)
+	if err != nil {
+		return err
+	}
+
+	// ? NOTE: The CSV only has places; keep each region's people, matched by name
+	for i := range locations {
+		j := slices.IndexFunc(d.Locations, func(l data.Location) bool { return l.Region == locations[i].Region })
+		if j >= 0 {
+			locations[i].Religions = d.Locations[j].Religions
+			locations[i].Ethnicities = d.Locations[j].Ethnicities
+			locations[i].Languages = d.Locations[j].Languages
+		}
+	}
+
+	out, err := replaceSection(raw, "locations", locations)
+	if err != nil {
+		return fmt.Errorf("%s: %w", dataPath, err)
+	}
+
+	return os.WriteFile(dataPath, out, 0o644)
+}
+
+// dataWidth is the line width data.json is formatted to: a value stays on
+// one line if it fits, otherwise its fields or items go one per line.
+const dataWidth = 80
+
+// replaceSection swaps the value of the top-level key in the JSON object raw
+// for value, formatted like the rest of data.json. Every other byte of raw
+// is kept as it is, so hand formatting elsewhere survives.
+func replaceSection(raw []byte, key string, value any) ([]byte, error) {
+	var enc bytes.Buffer
+	e := json.NewEncoder(&enc)
+	e.SetEscapeHTML(false)
+	if err := e.Encode(value); err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	for dec.More() {
+		k, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var old json.RawMessage
+		if err := dec.Decode(&old); err != nil {
+			return nil, err
+		}
+		if k != key {
+			continue
+		}
+
+		end := int(dec.InputOffset())
+		start := end - len(old)
+		tail := 0
+		if rest := bytes.TrimLeft(raw[end:], " \t\r\n"); len(rest) > 0 && rest[0] == ',' {
+			tail = 1
+		}
+		formatted, err := formatData(bytes.TrimSpace(enc.Bytes()), 1, len(`  "`+key+`": `), tail)
+		if err != nil {
+			return nil, err
+		}
+		return slices.Concat(raw[:start], []byte(formatted), raw[end:]), nil
+	}
+
+	return nil, fmt.Errorf("no top-level %q field", key)
+}
+
+// formatData formats the JSON value v, indented depth levels, on one line
+// if it fits in dataWidth after prefix columns and tail trailing ones.
+func formatData(v json.RawMessage, depth, prefix, tail int) (string, error) {
+	line, err := inlineData(v)
+	if err != nil {
+		return "", err
+	}
+	if (v[0] != '{' && v[0] != '[') || prefix+utf8.RuneCountInString(line)+tail <= dataWidth {
+		return line, nil
+	}
+
+	keys, values, err := dataMembers(v)
+	if err != nil {
+		return "", err
+	}
+	pad := strings.Repeat("  ", depth+1)
+	lines := make([]string, len(values))
+	for i, x := range values {
+		lead := pad
+		if keys != nil {
+			lead += keys[i] + ": "
+		}
+		t := 0
+		if i < len(values)-1 {
+			t = 1
+		}
+		item, err := formatData(x, depth+1, utf8.RuneCountInString(lead), t)
+		if err != nil {
+			return "", err
+		}
+		lines[i] = lead + item
+	}
+
+	closing := "]"
+	if keys != nil {
+		closing = "}"
+	}
+	return string(v[0]) + "\n" + strings.Join(lines, ",\n") + "\n" + strings.Repeat("  ", depth) + closing, nil
+}
+
+// inlineData writes the JSON value v on one line: "{ "a": 1, "b": 2 }" for
+// an object, "[1, 2]" for an array.
+func inlineData(v json.RawMessage) (string, error) {
+	if v[0] != '{' && v[0] != '[' {
+		return string(v), nil
+	}
+
+	keys, values, err := dataMembers(v)
+	if err != nil {
+		return "", err
+	}
+	items := make([]string, len(values))
+	for i, x := range values {
+		item, err := inlineData(x)
+		if err != nil {
+			return "", err
+		}
+		if keys != nil {
+			item = keys[i] + ": " + item
+		}
+		items[i] = item
+	}
+
+	switch {
+	case keys == nil:
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case len(items) == 0:
+		return "{}", nil
+	default:
+		return "{ " + strings.Join(items, ", ") + " }", nil
+	}
+}
+
+// dataMembers splits the JSON object or array v into its values, in order,
+// and for an object its keys, still quoted. keys is nil for an array.
+func dataMembers(v json.RawMessage) (keys []string, values []json.RawMessage, err error) {
+	dec := json.NewDecoder(bytes.NewReader(v))
+	open, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	object := open == json.Delim('{')
+	if object {
+		keys = []string{}
+	}
+
+	for dec.More() {
+		if object {
+			k, err := dec.Token()
+			if err != nil {
+				return nil, nil, err
+			}
+			quoted, err := json.Marshal(k)
+			if err != nil {
+				return nil, nil, err
+			}
+			keys = append(keys, string(quoted))
+		}
+		var x json.RawMessage
+		if err := dec.Decode(&x); err != nil {
+			return nil, nil, err
+		}
+		values = append(values, x)
+	}
+
+	return keys, values, nil
+}
+
+// runLint loads the embedded dataset with any files given as arguments
+// layered on top, the same way DATA_PATH does, and reports every problem.
+func runLint(args []string) int {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	replace := fs.Bool("replace", false, "apply files as DATA_MODE=replace instead of merge")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	d, err := data.Load(dataJSON, fs.Args(), *replace)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "lint:", err)
+		return 1
+	}
+
+	if err := d.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if _, err := loadVersions(d.Version); err != nil {
+		fmt.Fprintln(os.Stderr, "lint:", err)
+		return 1
+	}
+
+	fmt.Println("data OK")
+	return 0
+}
+
+// versionPattern and refYearPattern find the top-level "version" and
+// "ref_year" fields in data.json.
+var (
+	versionPattern = regexp.MustCompile(`(?m)^  "version": (\d+),$`)
+	refYearPattern = regexp.MustCompile(`(?m)^  "ref_year": (\d+),$`)
+)
+
+// runFreeze copies data.json into the versions directory under its current
+// version, then bumps the version in data.json so edits land in a new one,
+// counting ages and dates from this year.
+func runFreeze(args []string) int {
+	fs := flag.NewFlagSet("freeze", flag.ContinueOnError)
+	dataPath := fs.String("data", "data/data.json", "data.json to freeze")
+	dir := fs.String("dir", "data/versions", "directory of frozen versions")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	version, err := freezeData(*dataPath, *dir, time.Now().Year())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "freeze:", err)
+		return 1
+	}
+
+	fmt.Printf("Froze version %d into %s, %s is now version %d\n", version, *dir, *dataPath, version+1)
+	return 0
+}
+
+// freezeData does the work for runFreeze and returns the frozen version.
+// The bump edits the version and ref_year lines in place so the rest of
+// the file keeps its formatting.
+func freezeData(dataPath, dir string, year int) (int, error) {
+	raw, err := os.ReadFile(dataPath)
+	if err != nil {
+		return 0, err
+	}
+
+	m := versionPattern.FindSubmatchIndex(raw)
+	if m == nil {
+		return 0, fmt.Errorf("%s: no top-level \"version\" field", dataPath)
+	}
+	y := refYearPattern.FindSubmatchIndex(raw)
+	if y == nil || y[2] < m[3] {
+		return 0, fmt.Errorf("%s: no top-level \"ref_year\" field after \"version\"", dataPath)
+	}
+	version, err := strconv.Atoi(string(raw[m[2]:m[3]]))
+	if err != nil {
+		return 0, err
+	}
+
+	frozen := filepath.Join(dir, strconv.Itoa(version)+".json")
+	if _, err := os.Stat(frozen); err == nil {
+		return 0, fmt.Errorf("%s already exists", frozen)
+	}
+	if err := os.WriteFile(frozen, raw, 0o644); err != nil {
+		return 0, err
+	}
+
+	bumped := slices.Concat(
+		raw[:m[2]], []byte(strconv.Itoa(version+1)),
+		raw[m[3]:y[2]], []byte(strconv.Itoa(year)),
+		raw[y[3]:],
+	)
+	return version, os.WriteFile(dataPath, bumped, 0o644)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestReplaceSectionKeepsFormatting rewrites one section in data.json's
+// style and leaves every byte of the others alone.
+func TestReplaceSectionKeepsFormatting(t *testing.T) {
+	const in = `{
+  "version": 3,
+  "overseas": { "countries": [{ "name": "Japan", "php_rate": 0.37 }], "php_rate": 15.0 },
+  "locations": [],
+  "names": { "last_names": ["Santos"] }
+}
+`
+	type city struct {
+		Name      string   `json:"name"`
+		Barangays []string `json:"barangays"`
+	}
+	locations := []city{
+		{"Laoag", []string{"San Lorenzo"}},
+		{"Tuguegarao City", []string{"Annafunan East", "Annafunan West", "Bagay", "Buntun", "Caggay"}},
+	}
+
+	out, err := replaceSection([]byte(in), "locations", locations)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	const want = `{
+  "version": 3,
+  "overseas": { "countries": [{ "name": "Japan", "php_rate": 0.37 }], "php_rate": 15.0 },
+  "locations": [
+    { "name": "Laoag", "barangays": ["San Lorenzo"] },
+    {
+      "name": "Tuguegarao City",
+      "barangays": [
+        "Annafunan East",
+        "Annafunan West",
+        "Bagay",
+        "Buntun",
+        "Caggay"
+      ]
+    }
+  ],
+  "names": { "last_names": ["Santos"] }
+}
+`
+	if string(out) != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, out)
+	}
+
+	if _, err := replaceSection([]byte(in), "vehicles", locations); err == nil {
+		t.Errorf("expected an error for a missing section")
+	}
+}
+
+// TestFreezeData copies data.json as is and bumps the version and year in place.
+func TestFreezeData(t *testing.T) {
+	dir := t.TempDir()
+	dataPath := filepath.Join(dir, "data.json")
+	in := "{\n  \"version\": 3,\n  \"ref_year\": 2026,\n  \"names\": { \"last_names\": [\"Santos\"] }\n}\n"
+	if err := os.WriteFile(dataPath, []byte(in), 0o644); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	version, err := freezeData(dataPath, dir, 2027)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if version != 3 {
+		t.Errorf("expected version 3 to be frozen, got %d", version)
+	}
+
+	frozen, err := os.ReadFile(filepath.Join(dir, "3.json"))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if string(frozen) != in {
+		t.Errorf("expected 3.json to be an exact copy, got:\n%s", frozen)
+	}
+
+	bumped, err := os.ReadFile(dataPath)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	want := "{\n  \"version\": 4,\n  \"ref_year\": 2027,\n  \"names\": { \"last_names\": [\"Santos\"] }\n}\n"
+	if string(bumped) != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, bumped)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"io/fs"
 	"log/slog"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -16,10 +18,28 @@ const (
 	defaultMaxResults = 1000
 )
 
+// DATA_MODE values: how external datasets combine with the embedded one.
+const (
+	DataModeMerge   = "merge"   // append names, locations, etc. to the built-in lists
+	DataModeReplace = "replace" // sections present in the file replace the built-in ones
+)
+
 type Config struct {
 	Port    string
 	Env     string
 	Version string
+	// BaseURL prefixes generated links such as picture URLs.
+	// Empty keeps them relative to the API host.
+	BaseURL string
+
+	// DataPath is a JSON dataset file and DataDir a directory of them,
+	// applied on top of the embedded data at startup according to DataMode.
+	DataPath string
+	DataDir  string
+	DataMode string
+	// DataWatch reloads the dataset whenever an external file changes.
+	// SIGHUP triggers a reload regardless.
+	DataWatch bool
 
 	MaxResults int
 }
@@ -35,10 +55,23 @@ func LoadConfig() (*Config, error) {
 		Port:    os.Getenv("PORT"),
 		Env:     os.Getenv("ENV"),
 		Version: os.Getenv("VERSION"),
+		BaseURL: strings.TrimSuffix(os.Getenv("BASE_URL"), "/"),
+
+		DataPath: os.Getenv("DATA_PATH"),
+		DataDir:  os.Getenv("DATA_DIR"),
+		DataMode: os.Getenv("DATA_MODE"),
 
 		MaxResults: defaultMaxResults,
 	}
 
+	if watch := os.Getenv("DATA_WATCH"); watch != "" {
+		w, err := strconv.ParseBool(watch)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'DATA_WATCH' environment variable: %q", watch)
+		}
+		cfg.DataWatch = w
+	}
+
 	err := cfg.validate()
 	if err != nil {
 		return nil, err
@@ -49,7 +82,7 @@ func LoadConfig() (*Config, error) {
 
 // validate validates the configuration.
 // ENV must be "dev" or "prod"; VERSION is required in prod.
-// PORT defaults to 3000 if unset.
+// PORT defaults to 3000 and DATA_MODE to "merge" if unset.
 func (c *Config) validate() error {
 	switch c.Env {
 	case "":
@@ -71,6 +104,14 @@ func (c *Config) validate() error {
 		return fmt.Errorf("invalid 'ENV' environment variable: %q", c.Env)
 	}
 
+	switch c.DataMode {
+	case "":
+		c.DataMode = DataModeMerge
+	case DataModeMerge, DataModeReplace:
+	default:
+		return fmt.Errorf("invalid 'DATA_MODE' environment variable: %q", c.DataMode)
+	}
+
 	if c.Port == "" {
 		slog.Info(
 			"Missing PORT environment variable using default",
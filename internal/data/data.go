@@ -3,9 +3,28 @@ package data
 
 // Data mirrors data.json structure
 type Data struct {
+	// Version is bumped on the first edit after a release, so pinned seeds
+	// keep using the frozen copy in data/versions (see LoadVersions).
+	Version int `json:"version"`
+	// RefYear is the year ages and dates count from, as of January 1, so a
+	// version's people don't change with the calendar. Freezing a version
+	// moves the next one's to the current year.
+	RefYear         int             `json:"ref_year"`
 	Names           Names           `json:"names"`
 	Locations       []Location      `json:"locations"`
 	MobileProviders MobileProviders `json:"mobile_providers"`
+	EmailDomains    []EmailDomain   `json:"email_domains"`
+	Employment      Employment      `json:"employment"`
+	Overseas        Overseas        `json:"overseas"`
+	Education       Education       `json:"education"`
+	Finance         Finance         `json:"finance"`
+	Health          Health          `json:"health"`
+	Social          Social          `json:"social"`
+	Vehicles        []Vehicle       `json:"vehicles"`
+	Businesses      Businesses      `json:"businesses"`
+
+	// Hash identifies the exact bytes this dataset was loaded from; set by Load.
+	Hash string `json:"-"`
 }
 
 type Names struct {
@@ -18,16 +37,66 @@ type Names struct {
 type Titles struct {
 	Male   []string `json:"male"`
 	Female []string `json:"female"`
+	// Married is for women using their husband's surname (added in version 2).
+	Married []string `json:"married"`
 }
 
+// Location is a region and the places in it: region → province →
+// city/municipality → barangay.
+// Build it from data/locations.csv with `rpug data import-locations`.
 type Location struct {
 	Region string `json:"region"`
+	// Religions are for people whose ethnicity has no fixed religion.
+	Religions   []Religion       `json:"religions"`
+	Ethnicities []Ethnicity      `json:"ethnicities"`
+	Languages   []RegionLanguage `json:"languages"`
+	Provinces   []Province       `json:"provinces"`
+}
+
+// Religion is a religion, picked in proportion to Weight.
+type Religion struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+}
+
+// Ethnicity is an ethnolinguistic group, picked in proportion to Weight.
+// Language is its mother tongue. Religion, when set, is the religion of
+// every member, as with the Muslim Moro groups.
+type Ethnicity struct {
+	Name     string `json:"name"`
+	Weight   int    `json:"weight"`
+	Language string `json:"language"`
+	Religion string `json:"religion,omitempty"`
+}
+
+// RegionLanguage is a language Percent in 100 people in the region speak
+// besides their mother tongue, e.g. Filipino, English or a lingua franca.
+type RegionLanguage struct {
+	Name    string `json:"name"`
+	Percent int    `json:"percent"`
+}
+
+// Province is a province, or an NCR district, within a region.
+type Province struct {
+	Name   string `json:"name"`
 	Cities []City `json:"cities"`
 }
 
+// City is a city or municipality; Kind tells which.
 type City struct {
-	Name    string `json:"name"`
-	Zipcode string `json:"zipcode"`
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	Zipcode  string `json:"zipcode"`
+	AreaCode string `json:"area_code"`
+	// Centroid and a rough radius used to scatter coordinates inside the city.
+	Latitude  float64    `json:"latitude"`
+	Longitude float64    `json:"longitude"`
+	RadiusKm  float64    `json:"radius_km"`
+	Barangays []Barangay `json:"barangays"`
+}
+
+type Barangay struct {
+	Name string `json:"name"`
 }
 
 type MobileProviders struct {
@@ -35,3 +104,168 @@ type MobileProviders struct {
 	SmartTntSun []string `json:"smart_tnt_sun"`
 	Dito        []string `json:"dito"`
 }
+
+// EmailDomain is a mailbox provider, picked in proportion to Weight.
+type EmailDomain struct {
+	Domain string `json:"domain"`
+	Weight int    `json:"weight"`
+}
+
+// Employment is what employers, jobs and salaries are drawn from.
+type Employment struct {
+	// CompanyWords fill the {word} in employer templates, e.g. "Bayanihan".
+	CompanyWords []string   `json:"company_words"`
+	Industries   []Industry `json:"industries"`
+}
+
+// Industry is a sector of work, picked in proportion to Weight.
+// Employers are name templates: {word} is a company word, {last} a surname
+// and {city} the person's city, e.g. "{last} Enterprises".
+// Overseas industries are worked abroad; their employers are PH agencies.
+type Industry struct {
+	Name      string   `json:"name"`
+	Weight    int      `json:"weight"`
+	Overseas  bool     `json:"overseas,omitempty"`
+	Employers []string `json:"employers"`
+	Jobs      []Job    `json:"jobs"`
+}
+
+// Job is a job title with its monthly salary band in PHP.
+// RequiresDegree jobs only go to college graduates.
+type Job struct {
+	Title          string `json:"title"`
+	SalaryMin      int    `json:"salary_min"`
+	SalaryMax      int    `json:"salary_max"`
+	RequiresDegree bool   `json:"requires_degree,omitempty"`
+}
+
+// Overseas is where OFWs (overseas Filipino workers) are deployed.
+type Overseas struct {
+	Countries []Country `json:"countries"`
+}
+
+// Country is an OFW destination, picked in proportion to Weight.
+// Mobile numbers are +CallingCode, one of MobilePrefixes, then
+// SubscriberDigits random digits.
+type Country struct {
+	Name             string   `json:"name"`
+	Weight           int      `json:"weight"`
+	Cities           []string `json:"cities"`
+	Currency         string   `json:"currency"`
+	PHPRate          float64  `json:"php_rate"` // pesos per unit of Currency
+	CallingCode      string   `json:"calling_code"`
+	MobilePrefixes   []string `json:"mobile_prefixes"`
+	SubscriberDigits int      `json:"subscriber_digits"`
+	ContractMonths   []int    `json:"contract_months"`
+}
+
+// Education is what schools and courses are drawn from.
+// ElementarySchools and HighSchools are name templates: {city} is the
+// person's city and {barangay} their barangay, e.g. "{city} National High School".
+type Education struct {
+	ElementarySchools []string `json:"elementary_schools"`
+	HighSchools       []string `json:"high_schools"`
+	// Strands are the K-12 senior high school tracks, e.g. "STEM".
+	Strands  []string  `json:"strands"`
+	Courses  []Course  `json:"courses"`
+	Colleges []College `json:"colleges"`
+}
+
+// Course is a college degree program, picked in proportion to Weight.
+type Course struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+	Years  int    `json:"years"`
+}
+
+// College is a university or college in the region named Region.
+type College struct {
+	Name   string `json:"name"`
+	Region string `json:"region"`
+}
+
+// Finance is what bank accounts and e-wallets are drawn from. Cards come
+// from the generator's list of published test cards instead.
+type Finance struct {
+	Banks   []Bank   `json:"banks"`
+	Wallets []Wallet `json:"wallets"`
+}
+
+// Bank is a PH bank, picked in proportion to Weight. AccountFormat is how
+// its account numbers are written: each # is a digit, e.g. "####-####-##".
+type Bank struct {
+	Name          string `json:"name"`
+	Weight        int    `json:"weight"`
+	AccountFormat string `json:"account_format"`
+}
+
+// Wallet is an e-wallet bound to a mobile number, picked in proportion to Weight.
+type Wallet struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+}
+
+// Health is what blood types, allergies and conditions are drawn from.
+type Health struct {
+	BloodTypes []BloodType   `json:"blood_types"`
+	Allergies  []MedicalCode `json:"allergies"`
+	Conditions []MedicalCode `json:"conditions"`
+}
+
+// BloodType is an ABO/Rh blood type, picked in proportion to Weight.
+type BloodType struct {
+	Type   string `json:"type"`
+	Weight int    `json:"weight"`
+}
+
+// MedicalCode is an ICD-10-CM coded allergy or condition. Each adult aged
+// MinAge or more has it with a chance of Percent in 100.
+type MedicalCode struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Percent int    `json:"percent"`
+	MinAge  int    `json:"min_age,omitempty"`
+}
+
+// Social is what social media handles and websites are drawn from; the
+// generator puts them all on .example hosts.
+type Social struct {
+	Platforms      []Platform `json:"platforms"`
+	WebsitePercent int        `json:"website_percent"`
+}
+
+// Platform is a social network an adult has an account on with a chance of
+// Percent in 100. Handles are at most MaxLength characters, letters and
+// digits joined by one of Separators, e.g. "._".
+type Platform struct {
+	Name       string `json:"name"`
+	Percent    int    `json:"percent"`
+	MaxLength  int    `json:"max_length"`
+	Separators string `json:"separators"`
+}
+
+// Vehicle is a car model sold in the Philippines, picked in proportion to
+// Weight. Owned cars are model years from Since on.
+type Vehicle struct {
+	Make   string `json:"make"`
+	Model  string `json:"model"`
+	Weight int    `json:"weight"`
+	Since  int    `json:"since"`
+}
+
+// Businesses is what generated businesses are drawn from.
+type Businesses struct {
+	Industries []BusinessIndustry `json:"industries"`
+}
+
+// BusinessIndustry is a line of business, picked in proportion to Weight.
+// Names are templates using {first} and {last} for the owner's names,
+// {word} for a company word and {city} for where the business is.
+// SECPercent is the share registered with the SEC as partnerships or
+// corporations; the rest are sole proprietorships registered with the DTI.
+type BusinessIndustry struct {
+	Name       string   `json:"name"`
+	Weight     int      `json:"weight"`
+	SECPercent int      `json:"sec_percent"`
+	Names      []string `json:"names"`
+}
@@ -0,0 +1,273 @@
+package data
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// hashLength is how many hex digits of the sha256 Data.Hash keeps.
+const hashLength = 12
+
+// Files lists the external dataset files to apply: path (if set), then
+// every *.json in dir (if set) in lexical order.
+func Files(path, dir string) ([]string, error) {
+	var files []string
+	if path != "" {
+		files = append(files, path)
+	}
+
+	if dir != "" {
+		matches, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no *.json files in %s", dir)
+		}
+		slices.Sort(matches)
+		files = append(files, matches...)
+	}
+
+	return files, nil
+}
+
+// Load decodes the embedded dataset and applies each external file on top.
+// With replace set, sections present in a file replace the current ones
+// wholesale; otherwise the file is merged in with Merge.
+// The resulting Hash covers the embedded bytes and every file, in order.
+// Files cannot change Version or RefYear; a customised dataset is told
+// apart by its Hash.
+func Load(embedded []byte, files []string, replace bool) (*Data, error) {
+	var d Data
+	if err := json.Unmarshal(embedded, &d); err != nil {
+		return nil, fmt.Errorf("embedded data: %w", err)
+	}
+	version, refYear := d.Version, d.RefYear
+
+	h := sha256.New()
+	h.Write(embedded)
+	if replace {
+		h.Write([]byte("replace")) // same files, different result
+	}
+
+	for _, f := range files {
+		raw, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		h.Write(raw)
+
+		// ? NOTE: Decode into a fresh Data; decoding into d would keep the
+		// ? fields and slice elements a file leaves out
+		var ext Data
+		if err := json.Unmarshal(raw, &ext); err != nil {
+			return nil, fmt.Errorf("%s: %w", f, err)
+		}
+
+		if replace {
+			var sections map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &sections); err != nil {
+				return nil, fmt.Errorf("%s: %w", f, err)
+			}
+			d.replace(&ext, sections)
+			continue
+		}
+
+		d.Merge(&ext)
+	}
+
+	d.Version, d.RefYear = version, refYear
+	d.Hash = hex.EncodeToString(h.Sum(nil))[:hashLength]
+
+	return &d, nil
+}
+
+// replace swaps each top-level section listed in sections, the keys of the
+// file other was decoded from, into d. Sections the file omits stay as they are.
+func (d *Data) replace(other *Data, sections map[string]json.RawMessage) {
+	for key := range sections {
+		switch key {
+		case "names":
+			d.Names = other.Names
+		case "locations":
+			d.Locations = other.Locations
+		case "mobile_providers":
+			d.MobileProviders = other.MobileProviders
+		case "email_domains":
+			d.EmailDomains = other.EmailDomains
+		case "employment":
+			d.Employment = other.Employment
+		case "overseas":
+			d.Overseas = other.Overseas
+		case "education":
+			d.Education = other.Education
+		case "finance":
+			d.Finance = other.Finance
+		case "health":
+			d.Health = other.Health
+		case "social":
+			d.Social = other.Social
+		case "vehicles":
+			d.Vehicles = other.Vehicles
+		case "businesses":
+			d.Businesses = other.Businesses
+		}
+	}
+}
+
+// Merge appends everything in other that d does not have yet.
+// Regions, provinces and cities are matched by name so a file can add a
+// barangay to an existing city, or an ethnicity to a region; email domains
+// are matched by domain and take the weight from other. Industries,
+// countries and business industries are matched by name, keeping d's
+// weight, so a file can add jobs, cities or business names to an existing
+// one; courses, banks, wallets and social platforms are matched by name
+// too, vehicles by make and model, and allergies and conditions by code.
+// Blood types are only ever replaced.
+func (d *Data) Merge(other *Data) {
+	d.Names.Titles.Male = appendUnique(d.Names.Titles.Male, other.Names.Titles.Male...)
+	d.Names.Titles.Female = appendUnique(d.Names.Titles.Female, other.Names.Titles.Female...)
+	d.Names.Titles.Married = appendUnique(d.Names.Titles.Married, other.Names.Titles.Married...)
+	d.Names.MaleFirstNames = appendUnique(d.Names.MaleFirstNames, other.Names.MaleFirstNames...)
+	d.Names.FemaleFirstNames = appendUnique(d.Names.FemaleFirstNames, other.Names.FemaleFirstNames...)
+	d.Names.LastNames = appendUnique(d.Names.LastNames, other.Names.LastNames...)
+
+	d.MobileProviders.GlobeTM = appendUnique(d.MobileProviders.GlobeTM, other.MobileProviders.GlobeTM...)
+	d.MobileProviders.SmartTntSun = appendUnique(d.MobileProviders.SmartTntSun, other.MobileProviders.SmartTntSun...)
+	d.MobileProviders.Dito = appendUnique(d.MobileProviders.Dito, other.MobileProviders.Dito...)
+
+	for _, ed := range other.EmailDomains {
+		i := slices.IndexFunc(d.EmailDomains, func(x EmailDomain) bool { return x.Domain == ed.Domain })
+		if i < 0 {
+			d.EmailDomains = append(d.EmailDomains, ed)
+			continue
+		}
+		d.EmailDomains[i].Weight = ed.Weight
+	}
+
+	d.Employment.CompanyWords = appendUnique(d.Employment.CompanyWords, other.Employment.CompanyWords...)
+	for _, ind := range other.Employment.Industries {
+		i := slices.IndexFunc(d.Employment.Industries, func(x Industry) bool { return x.Name == ind.Name })
+		if i < 0 {
+			d.Employment.Industries = append(d.Employment.Industries, ind)
+			continue
+		}
+		dst := &d.Employment.Industries[i]
+		dst.Employers = appendUnique(dst.Employers, ind.Employers...)
+		dst.Jobs = appendUnique(dst.Jobs, ind.Jobs...)
+	}
+
+	for _, c := range other.Overseas.Countries {
+		i := slices.IndexFunc(d.Overseas.Countries, func(x Country) bool { return x.Name == c.Name })
+		if i < 0 {
+			d.Overseas.Countries = append(d.Overseas.Countries, c)
+			continue
+		}
+		d.Overseas.Countries[i].Cities = appendUnique(d.Overseas.Countries[i].Cities, c.Cities...)
+	}
+
+	d.Education.ElementarySchools = appendUnique(d.Education.ElementarySchools, other.Education.ElementarySchools...)
+	d.Education.HighSchools = appendUnique(d.Education.HighSchools, other.Education.HighSchools...)
+	d.Education.Strands = appendUnique(d.Education.Strands, other.Education.Strands...)
+	d.Education.Colleges = appendUnique(d.Education.Colleges, other.Education.Colleges...)
+	for _, c := range other.Education.Courses {
+		if !slices.ContainsFunc(d.Education.Courses, func(x Course) bool { return x.Name == c.Name }) {
+			d.Education.Courses = append(d.Education.Courses, c)
+		}
+	}
+
+	for _, bank := range other.Finance.Banks {
+		if !slices.ContainsFunc(d.Finance.Banks, func(x Bank) bool { return x.Name == bank.Name }) {
+			d.Finance.Banks = append(d.Finance.Banks, bank)
+		}
+	}
+	for _, w := range other.Finance.Wallets {
+		if !slices.ContainsFunc(d.Finance.Wallets, func(x Wallet) bool { return x.Name == w.Name }) {
+			d.Finance.Wallets = append(d.Finance.Wallets, w)
+		}
+	}
+
+	d.Health.Allergies = mergeCodes(d.Health.Allergies, other.Health.Allergies)
+	d.Health.Conditions = mergeCodes(d.Health.Conditions, other.Health.Conditions)
+
+	for _, pl := range other.Social.Platforms {
+		if !slices.ContainsFunc(d.Social.Platforms, func(x Platform) bool { return strings.EqualFold(x.Name, pl.Name) }) {
+			d.Social.Platforms = append(d.Social.Platforms, pl)
+		}
+	}
+
+	for _, veh := range other.Vehicles {
+		if !slices.ContainsFunc(d.Vehicles, func(x Vehicle) bool { return x.Make == veh.Make && x.Model == veh.Model }) {
+			d.Vehicles = append(d.Vehicles, veh)
+		}
+	}
+
+	for _, ind := range other.Businesses.Industries {
+		i := slices.IndexFunc(d.Businesses.Industries, func(x BusinessIndustry) bool { return x.Name == ind.Name })
+		if i < 0 {
+			d.Businesses.Industries = append(d.Businesses.Industries, ind)
+			continue
+		}
+		d.Businesses.Industries[i].Names = appendUnique(d.Businesses.Industries[i].Names, ind.Names...)
+	}
+
+	for _, loc := range other.Locations {
+		i := slices.IndexFunc(d.Locations, func(x Location) bool { return x.Region == loc.Region })
+		if i < 0 {
+			d.Locations = append(d.Locations, loc)
+			continue
+		}
+		dst := &d.Locations[i]
+		dst.Religions = appendUnique(dst.Religions, loc.Religions...)
+		dst.Ethnicities = appendUnique(dst.Ethnicities, loc.Ethnicities...)
+		dst.Languages = appendUnique(dst.Languages, loc.Languages...)
+		dst.mergeProvinces(loc.Provinces)
+	}
+}
+
+// mergeProvinces adds provinces, cities and barangays missing from l.
+func (l *Location) mergeProvinces(provinces []Province) {
+	for _, prov := range provinces {
+		i := slices.IndexFunc(l.Provinces, func(x Province) bool { return x.Name == prov.Name })
+		if i < 0 {
+			l.Provinces = append(l.Provinces, prov)
+			continue
+		}
+
+		dst := &l.Provinces[i]
+		for _, city := range prov.Cities {
+			j := slices.IndexFunc(dst.Cities, func(x City) bool { return x.Name == city.Name })
+			if j < 0 {
+				dst.Cities = append(dst.Cities, city)
+				continue
+			}
+			dst.Cities[j].Barangays = appendUnique(dst.Cities[j].Barangays, city.Barangays...)
+		}
+	}
+}
+
+// appendUnique appends the values of src that are not already in dst.
+func appendUnique[T comparable](dst []T, src ...T) []T {
+	for _, v := range src {
+		if !slices.Contains(dst, v) {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
+
+// mergeCodes appends the codes in src that dst does not have yet.
+func mergeCodes(dst, src []MedicalCode) []MedicalCode {
+	for _, c := range src {
+		if !slices.ContainsFunc(dst, func(x MedicalCode) bool { return x.Code == c.Code }) {
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
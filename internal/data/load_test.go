@@ -0,0 +1,127 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const embeddedTestData = `{
+  "names": {
+    "titles": { "male": ["Mr"], "female": ["Ms"] },
+    "male_first_names": ["Juan"],
+    "female_first_names": ["Maria"],
+    "last_names": ["Santos"]
+  },
+  "email_domains": [{ "domain": "gmail.com", "weight": 1 }]
+}`
+
+// writeDataFile writes content to a temp file and returns its path.
+func writeDataFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "extra.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	return path
+}
+
+// TestLoadMerge appends external names and domains to the embedded ones.
+func TestLoadMerge(t *testing.T) {
+	path := writeDataFile(t, `{
+  "names": { "last_names": ["Santos", "Dimaculangan"] },
+  "email_domains": [{ "domain": "acme.example", "weight": 5 }]
+}`)
+
+	d, err := Load([]byte(embeddedTestData), []string{path}, false)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if !slices.Equal(d.Names.LastNames, []string{"Santos", "Dimaculangan"}) {
+		t.Errorf("expected merged last names without duplicates, got: %v", d.Names.LastNames)
+	}
+	if !slices.Equal(d.Names.MaleFirstNames, []string{"Juan"}) {
+		t.Errorf("expected embedded first names to survive, got: %v", d.Names.MaleFirstNames)
+	}
+	if len(d.EmailDomains) != 2 {
+		t.Errorf("expected 2 email domains, got: %v", d.EmailDomains)
+	}
+}
+
+// TestLoadReplace swaps whole sections but keeps the ones the file omits.
+func TestLoadReplace(t *testing.T) {
+	path := writeDataFile(t, `{
+  "email_domains": [{ "domain": "acme.example", "weight": 5 }]
+}`)
+
+	d, err := Load([]byte(embeddedTestData), []string{path}, true)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(d.EmailDomains) != 1 || d.EmailDomains[0].Domain != "acme.example" {
+		t.Errorf("expected email domains to be replaced, got: %v", d.EmailDomains)
+	}
+	if !slices.Equal(d.Names.LastNames, []string{"Santos"}) {
+		t.Errorf("expected embedded last names to survive, got: %v", d.Names.LastNames)
+	}
+}
+
+// TestLoadReplaceFreshSections keeps nothing of a replaced section, not
+// even the fields its entries leave out.
+func TestLoadReplaceFreshSections(t *testing.T) {
+	embedded := `{
+  "locations": [{
+    "region": "Ilocos Region",
+    "ethnicities": [{ "name": "Ilocano", "weight": 1, "language": "Ilocano" }],
+    "provinces": [{ "name": "Ilocos Norte", "cities": [{ "name": "Laoag City" }] }]
+  }],
+  "email_domains": [{ "domain": "gmail.com", "weight": 55 }]
+}`
+	path := writeDataFile(t, `{
+  "locations": [{ "region": "Cordillera Administrative Region" }],
+  "email_domains": [{ "domain": "acme.example" }]
+}`)
+
+	d, err := Load([]byte(embedded), []string{path}, true)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(d.Locations) != 1 || d.Locations[0].Region != "Cordillera Administrative Region" {
+		t.Fatalf("expected the region to be replaced, got: %v", d.Locations)
+	}
+	if r := d.Locations[0]; len(r.Provinces) != 0 || len(r.Ethnicities) != 0 {
+		t.Errorf("expected no provinces or ethnicities from the old region, got: %v", r)
+	}
+	if len(d.EmailDomains) != 1 || d.EmailDomains[0].Weight != 0 {
+		t.Errorf("expected the replaced domain to keep no weight, got: %v", d.EmailDomains)
+	}
+}
+
+// TestLoadHash changes with the external data and the mode.
+func TestLoadHash(t *testing.T) {
+	embedded, err := Load([]byte(embeddedTestData), nil, false)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(embedded.Hash) != hashLength {
+		t.Fatalf("expected a %d-character hash, got: %q", hashLength, embedded.Hash)
+	}
+
+	path := writeDataFile(t, `{ "names": { "last_names": ["Dimaculangan"] } }`)
+	merged, err := Load([]byte(embeddedTestData), []string{path}, false)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	replaced, err := Load([]byte(embeddedTestData), []string{path}, true)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if merged.Hash == embedded.Hash || replaced.Hash == merged.Hash {
+		t.Errorf("expected distinct hashes, got: %s, %s, %s", embedded.Hash, merged.Hash, replaced.Hash)
+	}
+}
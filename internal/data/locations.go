@@ -0,0 +1,126 @@
+package data
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// City kinds, as stored in City.Kind.
+const (
+	KindCity         = "city"
+	KindMunicipality = "municipality"
+)
+
+// locationColumns is the header ParseLocations expects. Zip codes, area codes
+// and coordinates are only filled in for cities and municipalities.
+var locationColumns = []string{
+	"name", "level", "zipcode", "area_code", "latitude", "longitude", "radius_km",
+}
+
+// Geographic levels, as written in the "level" column.
+const (
+	levelRegion       = "region"
+	levelProvince     = "province"
+	levelDistrict     = "district" // NCR districts stand in for provinces
+	levelCity         = "city"
+	levelMunicipality = "municipality"
+	levelBarangay     = "barangay"
+)
+
+// ParseLocations builds the location hierarchy from a locations CSV.
+//
+// Rows are nested by file order: a province belongs to the region above it,
+// a city or municipality to the province above it, and a barangay to the
+// city above it. Independent cities go under their geographic province.
+func ParseLocations(r io.Reader) ([]Location, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(locationColumns)
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading locations header: %w", err)
+	}
+	for i, col := range locationColumns {
+		if header[i] != col {
+			return nil, fmt.Errorf("locations header column %d: expected %q, got %q", i+1, col, header[i])
+		}
+	}
+
+	var (
+		regions []Location
+		prov    *Province
+		city    *City
+	)
+
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("locations line %d: %w", line, err)
+		}
+
+		name, level := rec[0], rec[1]
+		switch level {
+		case levelRegion:
+			regions = append(regions, Location{Region: name})
+			prov, city = nil, nil
+
+		case levelProvince, levelDistrict:
+			if len(regions) == 0 {
+				return nil, fmt.Errorf("locations line %d: %s has no region above it", line, name)
+			}
+			region := &regions[len(regions)-1]
+			region.Provinces = append(region.Provinces, Province{Name: name})
+			prov, city = &region.Provinces[len(region.Provinces)-1], nil
+
+		case levelCity, levelMunicipality:
+			if prov == nil {
+				return nil, fmt.Errorf("locations line %d: %s has no province above it", line, name)
+			}
+			c, err := parseCityExtras(rec)
+			if err != nil {
+				return nil, fmt.Errorf("locations line %d: %w", line, err)
+			}
+			c.Name = name
+			c.Kind = KindMunicipality
+			if level == levelCity {
+				c.Kind = KindCity
+			}
+			prov.Cities = append(prov.Cities, c)
+			city = &prov.Cities[len(prov.Cities)-1]
+
+		case levelBarangay:
+			if city == nil {
+				return nil, fmt.Errorf("locations line %d: %s has no city above it", line, name)
+			}
+			city.Barangays = append(city.Barangays, Barangay{Name: name})
+
+		default:
+			return nil, fmt.Errorf("locations line %d: unknown level %q", line, level)
+		}
+	}
+
+	return regions, nil
+}
+
+// parseCityExtras reads the zip code, area code and coordinates of a city or
+// municipality row.
+func parseCityExtras(rec []string) (City, error) {
+	c := City{Zipcode: rec[2], AreaCode: rec[3]}
+
+	for i, dst := range []*float64{&c.Latitude, &c.Longitude, &c.RadiusKm} {
+		col := 4 + i
+		v, err := strconv.ParseFloat(rec[col], 64)
+		if err != nil {
+			return City{}, fmt.Errorf("%s %q: %w", locationColumns[col], rec[col], err)
+		}
+		*dst = v
+	}
+
+	return c, nil
+}
@@ -0,0 +1,63 @@
+package data
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestParseLocations builds a small tree, nesting each row under the one
+// above it, with an independent city under its geographic province.
+func TestParseLocations(t *testing.T) {
+	const csv = `name,level,zipcode,area_code,latitude,longitude,radius_km
+Central Visayas,region,,,,,
+Cebu,province,,,,,
+Cebu City,city,6000,032,10.3157,123.8854,8
+Lahug,barangay,,,,,
+Moalboal,municipality,6032,032,9.9400,123.3960,4
+Basdiot,barangay,,,,,
+`
+	locs, err := ParseLocations(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(locs) != 1 || len(locs[0].Provinces) != 1 {
+		t.Fatalf("expected 1 region with 1 province, got: %+v", locs)
+	}
+	cities := locs[0].Provinces[0].Cities
+	if len(cities) != 2 {
+		t.Fatalf("expected 2 cities under Cebu, got: %d", len(cities))
+	}
+	if c := cities[0]; c.Name != "Cebu City" || c.Kind != KindCity || c.Barangays[0].Name != "Lahug" {
+		t.Errorf("unexpected independent city: %+v", c)
+	}
+	if c := cities[1]; c.Kind != KindMunicipality || c.Zipcode != "6032" || c.RadiusKm != 4 || c.Barangays[0].Name != "Basdiot" {
+		t.Errorf("unexpected municipality: %+v", c)
+	}
+}
+
+// TestParseLocationsOrphan rejects a barangay with no city above it.
+func TestParseLocationsOrphan(t *testing.T) {
+	const csv = `name,level,zipcode,area_code,latitude,longitude,radius_km
+Central Visayas,region,,,,,
+Cebu,province,,,,,
+Lahug,barangay,,,,,
+`
+	if _, err := ParseLocations(strings.NewReader(csv)); err == nil {
+		t.Errorf("expected an error for an orphan barangay")
+	}
+}
+
+// TestParseLocationsCheckedIn keeps data/locations.csv importable.
+func TestParseLocationsCheckedIn(t *testing.T) {
+	f, err := os.Open("../../data/locations.csv")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	defer f.Close()
+
+	if _, err := ParseLocations(f); err != nil {
+		t.Errorf("expected data/locations.csv to parse, got: %v", err)
+	}
+}
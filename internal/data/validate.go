@@ -0,0 +1,679 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	templatePattern = regexp.MustCompile(`\{[^}]*\}`)
+	zipcodePattern  = regexp.MustCompile(`^\d{4}$`)
+	areaCodePattern = regexp.MustCompile(`^0\d{1,2}$`)
+	prefixPattern   = regexp.MustCompile(`^09\d{2}$`)
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	digitsPattern   = regexp.MustCompile(`^\d+$`)
+	accountPattern  = regexp.MustCompile(`^#[# -]*#$`)
+	icdPattern      = regexp.MustCompile(`^[A-Z]\d{2}(\.\d{1,4})?$`)
+	platformPattern = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+	// slugPattern matches a character emails, usernames and handles keep
+	// of a name; the rest are dropped.
+	slugPattern = regexp.MustCompile(`[A-Za-z0-9ñÑ]`)
+)
+
+// Rough bounding box of the Philippines, used to catch swapped or mistyped coordinates.
+const (
+	minLatitude  = 4.0
+	maxLatitude  = 21.5
+	minLongitude = 116.0
+	maxLongitude = 127.0
+)
+
+// Problem is one issue found by Validate, located by its JSON path
+// (e.g. "locations[3].provinces[0].cities[2].zipcode").
+type Problem struct {
+	Path    string
+	Message string
+}
+
+func (p Problem) Error() string {
+	return p.Path + ": " + p.Message
+}
+
+// validator collects Problems while walking a Data.
+type validator struct {
+	problems []error
+}
+
+func (v *validator) addf(path, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// strings reports an empty list, blank entries and duplicates.
+func (v *validator) strings(path string, list []string) {
+	if len(list) == 0 {
+		v.addf(path, "must not be empty")
+	}
+	seen := make(map[string]int, len(list))
+	for i, s := range list {
+		if s == "" {
+			v.addf(fmt.Sprintf("%s[%d]", path, i), "must not be blank")
+		}
+		if first, dup := seen[s]; dup {
+			v.addf(fmt.Sprintf("%s[%d]", path, i), "duplicates %s[%d] (%q)", path, first, s)
+			continue
+		}
+		seen[s] = i
+	}
+}
+
+// names checks a list of person names like strings does, and that each
+// keeps something once slugged into an email.
+func (v *validator) names(path string, list []string) {
+	v.strings(path, list)
+	for i, name := range list {
+		if name != "" && !slugPattern.MatchString(name) {
+			v.addf(fmt.Sprintf("%s[%d]", path, i), "must have an ASCII letter or digit, got %q", name)
+		}
+	}
+}
+
+// templates checks a list of name templates like strings does, and that
+// each only uses the allowed placeholders.
+func (v *validator) templates(path string, list []string, allowed map[string]bool) {
+	v.strings(path, list)
+	for i, tmpl := range list {
+		for _, ph := range templatePattern.FindAllString(tmpl, -1) {
+			if !allowed[ph] {
+				v.addf(fmt.Sprintf("%s[%d]", path, i), "unknown placeholder %s", ph)
+			}
+		}
+	}
+}
+
+// Validate checks everything the generator relies on: lists it draws from
+// are non-empty, codes are well-formed and nothing is listed twice.
+// It returns every Problem found, joined with errors.Join, or nil.
+func (d *Data) Validate() error {
+	var v validator
+
+	if d.Version < 1 {
+		v.addf("version", "must be at least 1")
+	}
+	if d.RefYear < 1 {
+		v.addf("ref_year", "must be set")
+	}
+
+	v.strings("names.titles.male", d.Names.Titles.Male)
+	v.strings("names.titles.female", d.Names.Titles.Female)
+	v.strings("names.titles.married", d.Names.Titles.Married)
+	v.names("names.male_first_names", d.Names.MaleFirstNames)
+	v.names("names.female_first_names", d.Names.FemaleFirstNames)
+	v.names("names.last_names", d.Names.LastNames)
+
+	d.validateLocations(&v)
+	d.validateProviders(&v)
+	d.validateEmailDomains(&v)
+	d.validateEmployment(&v)
+	d.validateOverseas(&v)
+	d.validateEducation(&v)
+	d.validateFinance(&v)
+	d.validateHealth(&v)
+	d.validateSocial(&v)
+	d.validateVehicles(&v)
+	d.validateBusinesses(&v)
+
+	return errors.Join(v.problems...)
+}
+
+// validateLocations walks the location tree. Names must be unique among
+// their siblings, since nothing else tells two places apart.
+func (d *Data) validateLocations(v *validator) {
+	if len(d.Locations) == 0 {
+		v.addf("locations", "must not be empty")
+	}
+
+	regions := make(map[string]int, len(d.Locations))
+	for ri, loc := range d.Locations {
+		rp := fmt.Sprintf("locations[%d]", ri)
+		if loc.Region == "" {
+			v.addf(rp+".region", "must not be blank")
+		} else if first, dup := regions[loc.Region]; dup {
+			v.addf(rp+".region", "duplicates locations[%d] (%q)", first, loc.Region)
+		} else {
+			regions[loc.Region] = ri
+		}
+		if len(loc.Provinces) == 0 {
+			v.addf(rp+".provinces", "must not be empty")
+		}
+		validateRegionPeople(v, rp, loc)
+
+		provinces := make(map[string]int, len(loc.Provinces))
+		for pi, prov := range loc.Provinces {
+			pp := fmt.Sprintf("%s.provinces[%d]", rp, pi)
+			if prov.Name == "" {
+				v.addf(pp+".name", "must not be blank")
+			} else if first, dup := provinces[prov.Name]; dup {
+				v.addf(pp+".name", "duplicates %s.provinces[%d] (%q)", rp, first, prov.Name)
+			} else {
+				provinces[prov.Name] = pi
+			}
+			if len(prov.Cities) == 0 {
+				v.addf(pp+".cities", "must not be empty")
+			}
+
+			cities := make(map[string]int, len(prov.Cities))
+			for ci, city := range prov.Cities {
+				cp := fmt.Sprintf("%s.cities[%d]", pp, ci)
+				if first, dup := cities[city.Name]; dup {
+					v.addf(cp+".name", "duplicates %s.cities[%d] (%q)", pp, first, city.Name)
+				} else {
+					cities[city.Name] = ci
+				}
+				validateCity(v, cp, city)
+
+				barangays := make(map[string]int, len(city.Barangays))
+				for bi, brgy := range city.Barangays {
+					bp := fmt.Sprintf("%s.barangays[%d]", cp, bi)
+					if brgy.Name == "" {
+						v.addf(bp+".name", "must not be blank")
+					} else if first, dup := barangays[brgy.Name]; dup {
+						v.addf(bp+".name", "duplicates %s.barangays[%d] (%q)", cp, first, brgy.Name)
+					} else {
+						barangays[brgy.Name] = bi
+					}
+				}
+			}
+		}
+	}
+}
+
+// validateCity checks the fields of one city other than its barangays' contents.
+func validateCity(v *validator, path string, city City) {
+	if city.Name == "" {
+		v.addf(path+".name", "must not be blank")
+	}
+	if city.Kind != KindCity && city.Kind != KindMunicipality {
+		v.addf(path+".kind", "must be %q or %q, got %q", KindCity, KindMunicipality, city.Kind)
+	}
+	if !zipcodePattern.MatchString(city.Zipcode) {
+		v.addf(path+".zipcode", "must be 4 digits, got %q", city.Zipcode)
+	}
+	if !areaCodePattern.MatchString(city.AreaCode) {
+		v.addf(path+".area_code", "must look like \"02\" or \"032\", got %q", city.AreaCode)
+	}
+	if city.Latitude < minLatitude || city.Latitude > maxLatitude {
+		v.addf(path+".latitude", "%v is outside the Philippines", city.Latitude)
+	}
+	if city.Longitude < minLongitude || city.Longitude > maxLongitude {
+		v.addf(path+".longitude", "%v is outside the Philippines", city.Longitude)
+	}
+	if city.RadiusKm <= 0 {
+		v.addf(path+".radius_km", "must be positive, got %v", city.RadiusKm)
+	}
+	if len(city.Barangays) == 0 {
+		v.addf(path+".barangays", "must not be empty")
+	}
+}
+
+// validateRegionPeople checks a region can produce an ethnicity, a religion
+// and other languages.
+func validateRegionPeople(v *validator, path string, loc Location) {
+	if len(loc.Ethnicities) == 0 {
+		v.addf(path+".ethnicities", "must not be empty")
+	}
+	total, fixed := 0, true
+	for i, e := range loc.Ethnicities {
+		p := fmt.Sprintf("%s.ethnicities[%d]", path, i)
+		if e.Name == "" || e.Language == "" {
+			v.addf(p, "name and language must not be blank")
+		}
+		if e.Weight < 0 {
+			v.addf(p+".weight", "must not be negative, got %d", e.Weight)
+		}
+		total += max(e.Weight, 0)
+		fixed = fixed && e.Religion != ""
+	}
+	if len(loc.Ethnicities) > 0 && total == 0 {
+		v.addf(path+".ethnicities", "weights must add up to more than zero")
+	}
+
+	// ? NOTE: Religions may be left out only if every ethnicity has one fixed
+	if len(loc.Religions) == 0 && !fixed {
+		v.addf(path+".religions", "must not be empty")
+	}
+	total = 0
+	for i, r := range loc.Religions {
+		p := fmt.Sprintf("%s.religions[%d]", path, i)
+		if r.Name == "" {
+			v.addf(p+".name", "must not be blank")
+		}
+		if r.Weight < 0 {
+			v.addf(p+".weight", "must not be negative, got %d", r.Weight)
+		}
+		total += max(r.Weight, 0)
+	}
+	if len(loc.Religions) > 0 && total == 0 {
+		v.addf(path+".religions", "weights must add up to more than zero")
+	}
+
+	for i, l := range loc.Languages {
+		p := fmt.Sprintf("%s.languages[%d]", path, i)
+		if l.Name == "" {
+			v.addf(p+".name", "must not be blank")
+		}
+		if l.Percent < 0 || l.Percent > 100 {
+			v.addf(p+".percent", "must be 0-100, got %d", l.Percent)
+		}
+	}
+}
+
+// validateProviders checks mobile prefixes. Smart and DITO legitimately
+// share a few prefixes, so duplicates are only checked within each provider.
+func (d *Data) validateProviders(v *validator) {
+	mp := d.MobileProviders
+	if len(mp.GlobeTM)+len(mp.SmartTntSun)+len(mp.Dito) == 0 {
+		v.addf("mobile_providers", "must list at least one prefix")
+	}
+
+	providers := []struct {
+		path string
+		list []string
+	}{
+		{"mobile_providers.globe_tm", mp.GlobeTM},
+		{"mobile_providers.smart_tnt_sun", mp.SmartTntSun},
+		{"mobile_providers.dito", mp.Dito},
+	}
+	for _, pr := range providers {
+		seen := make(map[string]bool, len(pr.list))
+		for i, prefix := range pr.list {
+			p := fmt.Sprintf("%s[%d]", pr.path, i)
+			if !prefixPattern.MatchString(prefix) {
+				v.addf(p, "must be a 4-digit prefix like \"0917\", got %q", prefix)
+			}
+			if seen[prefix] {
+				v.addf(p, "duplicate prefix %q", prefix)
+			}
+			seen[prefix] = true
+		}
+	}
+}
+
+// validateEmailDomains checks the weighted domain list can be drawn from.
+func (d *Data) validateEmailDomains(v *validator) {
+	if len(d.EmailDomains) == 0 {
+		v.addf("email_domains", "must not be empty")
+	}
+
+	total := 0
+	seen := make(map[string]int, len(d.EmailDomains))
+	for i, ed := range d.EmailDomains {
+		p := fmt.Sprintf("email_domains[%d]", i)
+		if ed.Domain == "" {
+			v.addf(p+".domain", "must not be blank")
+		}
+		if first, dup := seen[ed.Domain]; dup {
+			v.addf(p+".domain", "duplicates email_domains[%d] (%q)", first, ed.Domain)
+		}
+		seen[ed.Domain] = i
+		if ed.Weight < 0 {
+			v.addf(p+".weight", "must not be negative, got %d", ed.Weight)
+		}
+		total += max(ed.Weight, 0)
+	}
+	if len(d.EmailDomains) > 0 && total == 0 {
+		v.addf("email_domains", "weights must add up to more than zero")
+	}
+}
+
+// employerPlaceholders are the fields an employer template may use.
+var employerPlaceholders = map[string]bool{"{word}": true, "{last}": true, "{city}": true}
+
+// validateEmployment checks every industry can produce an employer, a job
+// and a salary.
+func (d *Data) validateEmployment(v *validator) {
+	v.strings("employment.company_words", d.Employment.CompanyWords)
+	if len(d.Employment.Industries) == 0 {
+		v.addf("employment.industries", "must not be empty")
+	}
+
+	total := 0
+	seen := make(map[string]int, len(d.Employment.Industries))
+	for i, ind := range d.Employment.Industries {
+		p := fmt.Sprintf("employment.industries[%d]", i)
+		if ind.Name == "" {
+			v.addf(p+".name", "must not be blank")
+		}
+		if first, dup := seen[ind.Name]; dup {
+			v.addf(p+".name", "duplicates employment.industries[%d] (%q)", first, ind.Name)
+		}
+		seen[ind.Name] = i
+		if ind.Weight < 0 {
+			v.addf(p+".weight", "must not be negative, got %d", ind.Weight)
+		}
+		total += max(ind.Weight, 0)
+
+		v.templates(p+".employers", ind.Employers, employerPlaceholders)
+
+		if len(ind.Jobs) == 0 {
+			v.addf(p+".jobs", "must not be empty")
+		}
+		for j, job := range ind.Jobs {
+			jp := fmt.Sprintf("%s.jobs[%d]", p, j)
+			if job.Title == "" {
+				v.addf(jp+".title", "must not be blank")
+			}
+			if job.SalaryMin <= 0 || job.SalaryMax < job.SalaryMin {
+				v.addf(jp, "salary band must be positive with min <= max, got %d-%d", job.SalaryMin, job.SalaryMax)
+			}
+		}
+	}
+	if len(d.Employment.Industries) > 0 && total == 0 {
+		v.addf("employment.industries", "weights must add up to more than zero")
+	}
+}
+
+// validateOverseas checks every country can produce a city, a phone number
+// and a contract, and that overseas industries have somewhere to go.
+func (d *Data) validateOverseas(v *validator) {
+	countries := d.Overseas.Countries
+	if len(countries) == 0 && slices.ContainsFunc(d.Employment.Industries, func(i Industry) bool { return i.Overseas }) {
+		v.addf("overseas.countries", "must not be empty while an industry is overseas")
+	}
+
+	total := 0
+	seen := make(map[string]int, len(countries))
+	for i, c := range countries {
+		p := fmt.Sprintf("overseas.countries[%d]", i)
+		if c.Name == "" {
+			v.addf(p+".name", "must not be blank")
+		}
+		if first, dup := seen[c.Name]; dup {
+			v.addf(p+".name", "duplicates overseas.countries[%d] (%q)", first, c.Name)
+		}
+		seen[c.Name] = i
+		if c.Weight < 0 {
+			v.addf(p+".weight", "must not be negative, got %d", c.Weight)
+		}
+		total += max(c.Weight, 0)
+
+		v.strings(p+".cities", c.Cities)
+		if !currencyPattern.MatchString(c.Currency) {
+			v.addf(p+".currency", "must be a 3-letter ISO 4217 code, got %q", c.Currency)
+		}
+		if c.PHPRate <= 0 {
+			v.addf(p+".php_rate", "must be positive, got %v", c.PHPRate)
+		}
+		if !digitsPattern.MatchString(c.CallingCode) || len(c.CallingCode) > 3 {
+			v.addf(p+".calling_code", "must be 1-3 digits, got %q", c.CallingCode)
+		}
+		if len(c.MobilePrefixes) == 0 {
+			v.addf(p+".mobile_prefixes", "must not be empty")
+		}
+		for j, prefix := range c.MobilePrefixes {
+			if !digitsPattern.MatchString(prefix) {
+				v.addf(fmt.Sprintf("%s.mobile_prefixes[%d]", p, j), "must be digits, got %q", prefix)
+			}
+		}
+		if c.SubscriberDigits < 4 || c.SubscriberDigits > 10 {
+			v.addf(p+".subscriber_digits", "must be 4-10, got %d", c.SubscriberDigits)
+		}
+		if len(c.ContractMonths) == 0 {
+			v.addf(p+".contract_months", "must not be empty")
+		}
+		for j, m := range c.ContractMonths {
+			if m < 1 {
+				v.addf(fmt.Sprintf("%s.contract_months[%d]", p, j), "must be positive, got %d", m)
+			}
+		}
+	}
+	if len(countries) > 0 && total == 0 {
+		v.addf("overseas.countries", "weights must add up to more than zero")
+	}
+}
+
+// schoolPlaceholders are the fields an elementary or high school template may use.
+var schoolPlaceholders = map[string]bool{"{city}": true, "{barangay}": true}
+
+// validateEducation checks every attainment can produce a school, and that
+// colleges are in regions the dataset has.
+func (d *Data) validateEducation(v *validator) {
+	ed := d.Education
+	v.templates("education.elementary_schools", ed.ElementarySchools, schoolPlaceholders)
+	v.templates("education.high_schools", ed.HighSchools, schoolPlaceholders)
+	v.strings("education.strands", ed.Strands)
+
+	if len(ed.Courses) == 0 {
+		v.addf("education.courses", "must not be empty")
+	}
+	total := 0
+	for i, c := range ed.Courses {
+		p := fmt.Sprintf("education.courses[%d]", i)
+		if c.Name == "" {
+			v.addf(p+".name", "must not be blank")
+		}
+		if c.Weight < 0 {
+			v.addf(p+".weight", "must not be negative, got %d", c.Weight)
+		}
+		total += max(c.Weight, 0)
+		if c.Years < 2 || c.Years > 6 {
+			v.addf(p+".years", "must be 2-6, got %d", c.Years)
+		}
+	}
+	if len(ed.Courses) > 0 && total == 0 {
+		v.addf("education.courses", "weights must add up to more than zero")
+	}
+
+	if len(ed.Colleges) == 0 {
+		v.addf("education.colleges", "must not be empty")
+	}
+	for i, c := range ed.Colleges {
+		p := fmt.Sprintf("education.colleges[%d]", i)
+		if c.Name == "" {
+			v.addf(p+".name", "must not be blank")
+		}
+		if !slices.ContainsFunc(d.Locations, func(l Location) bool { return l.Region == c.Region }) {
+			v.addf(p+".region", "must be a region in locations, got %q", c.Region)
+		}
+	}
+}
+
+// validateFinance checks banks and wallets can be drawn from, and every
+// account format has a sensible number of digits.
+func (d *Data) validateFinance(v *validator) {
+	if len(d.Finance.Banks) == 0 {
+		v.addf("finance.banks", "must not be empty")
+	}
+	bankTotal := 0
+	for i, bank := range d.Finance.Banks {
+		p := fmt.Sprintf("finance.banks[%d]", i)
+		if bank.Name == "" {
+			v.addf(p+".name", "must not be blank")
+		}
+		if bank.Weight < 0 {
+			v.addf(p+".weight", "must not be negative, got %d", bank.Weight)
+		}
+		bankTotal += max(bank.Weight, 0)
+		if n := strings.Count(bank.AccountFormat, "#"); !accountPattern.MatchString(bank.AccountFormat) || n < 8 || n > 16 {
+			v.addf(p+".account_format", "must be 8-16 #s with optional dashes or spaces, got %q", bank.AccountFormat)
+		}
+	}
+	if len(d.Finance.Banks) > 0 && bankTotal == 0 {
+		v.addf("finance.banks", "weights must add up to more than zero")
+	}
+
+	if len(d.Finance.Wallets) == 0 {
+		v.addf("finance.wallets", "must not be empty")
+	}
+	walletTotal := 0
+	for i, w := range d.Finance.Wallets {
+		p := fmt.Sprintf("finance.wallets[%d]", i)
+		if w.Name == "" {
+			v.addf(p+".name", "must not be blank")
+		}
+		if w.Weight < 0 {
+			v.addf(p+".weight", "must not be negative, got %d", w.Weight)
+		}
+		walletTotal += max(w.Weight, 0)
+	}
+	if len(d.Finance.Wallets) > 0 && walletTotal == 0 {
+		v.addf("finance.wallets", "weights must add up to more than zero")
+	}
+}
+
+// validateHealth checks blood types can be drawn from and every allergy and
+// condition has a well-formed code and a chance.
+func (d *Data) validateHealth(v *validator) {
+	if len(d.Health.BloodTypes) == 0 {
+		v.addf("health.blood_types", "must not be empty")
+	}
+	total := 0
+	for i, bt := range d.Health.BloodTypes {
+		p := fmt.Sprintf("health.blood_types[%d]", i)
+		if bt.Type == "" {
+			v.addf(p+".type", "must not be blank")
+		}
+		if bt.Weight < 0 {
+			v.addf(p+".weight", "must not be negative, got %d", bt.Weight)
+		}
+		total += max(bt.Weight, 0)
+	}
+	if len(d.Health.BloodTypes) > 0 && total == 0 {
+		v.addf("health.blood_types", "weights must add up to more than zero")
+	}
+
+	codes := func(path string, list []MedicalCode) {
+		seen := make(map[string]int, len(list))
+		for i, c := range list {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			if !icdPattern.MatchString(c.Code) {
+				v.addf(p+".code", "must be an ICD-10 code like J45 or Z91.013, got %q", c.Code)
+			}
+			if first, dup := seen[c.Code]; dup {
+				v.addf(p+".code", "duplicates %s[%d] (%s)", path, first, c.Code)
+			}
+			seen[c.Code] = i
+			if c.Name == "" {
+				v.addf(p+".name", "must not be blank")
+			}
+			if c.Percent < 0 || c.Percent > 100 {
+				v.addf(p+".percent", "must be 0-100, got %d", c.Percent)
+			}
+			if c.MinAge < 0 {
+				v.addf(p+".min_age", "must not be negative, got %d", c.MinAge)
+			}
+		}
+	}
+	codes("health.allergies", d.Health.Allergies)
+	codes("health.conditions", d.Health.Conditions)
+}
+
+// handleSeparators are the only characters allowed between the parts of a
+// handle; both are accepted by every major platform.
+const handleSeparators = "._"
+
+// validateSocial checks every platform makes a host name and sensible
+// handles, and the chances are percentages.
+func (d *Data) validateSocial(v *validator) {
+	if len(d.Social.Platforms) == 0 {
+		v.addf("social.platforms", "must not be empty")
+	}
+	seen := make(map[string]int, len(d.Social.Platforms))
+	for i, pl := range d.Social.Platforms {
+		p := fmt.Sprintf("social.platforms[%d]", i)
+		if !platformPattern.MatchString(pl.Name) {
+			v.addf(p+".name", "must be letters and digits only, got %q", pl.Name)
+		}
+		key := strings.ToLower(pl.Name)
+		if first, dup := seen[key]; dup {
+			v.addf(p+".name", "duplicates social.platforms[%d] (%s)", first, pl.Name)
+		}
+		seen[key] = i
+		if pl.Percent < 0 || pl.Percent > 100 {
+			v.addf(p+".percent", "must be 0-100, got %d", pl.Percent)
+		}
+		if pl.MaxLength < 8 || pl.MaxLength > 50 {
+			v.addf(p+".max_length", "must be 8-50, got %d", pl.MaxLength)
+		}
+		if strings.Trim(pl.Separators, handleSeparators) != "" {
+			v.addf(p+".separators", "must only use %q, got %q", handleSeparators, pl.Separators)
+		}
+	}
+	if w := d.Social.WebsitePercent; w < 0 || w > 100 {
+		v.addf("social.website_percent", "must be 0-100, got %d", w)
+	}
+}
+
+// firstPlateYear is the oldest model year a vehicle can have: LTO's
+// three-letter plates, the oldest format the generator writes, date from 1981.
+const firstPlateYear = 1981
+
+// validateVehicles checks the weighted car models can be drawn from.
+func (d *Data) validateVehicles(v *validator) {
+	if len(d.Vehicles) == 0 {
+		v.addf("vehicles", "must not be empty")
+	}
+	total := 0
+	seen := make(map[string]int, len(d.Vehicles))
+	for i, veh := range d.Vehicles {
+		p := fmt.Sprintf("vehicles[%d]", i)
+		if veh.Make == "" {
+			v.addf(p+".make", "must not be blank")
+		}
+		if veh.Model == "" {
+			v.addf(p+".model", "must not be blank")
+		}
+		name := veh.Make + " " + veh.Model
+		if first, dup := seen[name]; dup {
+			v.addf(p+".model", "duplicates vehicles[%d] (%s)", first, name)
+		}
+		seen[name] = i
+		if veh.Weight < 0 {
+			v.addf(p+".weight", "must not be negative, got %d", veh.Weight)
+		}
+		total += max(veh.Weight, 0)
+		if veh.Since < firstPlateYear {
+			v.addf(p+".since", "must be %d or later, got %d", firstPlateYear, veh.Since)
+		}
+	}
+	if len(d.Vehicles) > 0 && total == 0 {
+		v.addf("vehicles", "weights must add up to more than zero")
+	}
+}
+
+// businessPlaceholders are the fields a business name template may use.
+var businessPlaceholders = map[string]bool{"{first}": true, "{last}": true, "{word}": true, "{city}": true}
+
+// validateBusinesses checks business industries can be drawn from and
+// their name templates only use known placeholders.
+func (d *Data) validateBusinesses(v *validator) {
+	industries := d.Businesses.Industries
+	if len(industries) == 0 {
+		v.addf("businesses.industries", "must not be empty")
+	}
+	total := 0
+	seen := make(map[string]int, len(industries))
+	for i, ind := range industries {
+		p := fmt.Sprintf("businesses.industries[%d]", i)
+		if ind.Name == "" {
+			v.addf(p+".name", "must not be blank")
+		}
+		if first, dup := seen[ind.Name]; dup {
+			v.addf(p+".name", "duplicates businesses.industries[%d] (%s)", first, ind.Name)
+		}
+		seen[ind.Name] = i
+		if ind.Weight < 0 {
+			v.addf(p+".weight", "must not be negative, got %d", ind.Weight)
+		}
+		total += max(ind.Weight, 0)
+		if ind.SECPercent < 0 || ind.SECPercent > 100 {
+			v.addf(p+".sec_percent", "must be 0-100, got %d", ind.SECPercent)
+		}
+		v.templates(p+".names", ind.Names, businessPlaceholders)
+	}
+	if len(industries) > 0 && total == 0 {
+		v.addf("businesses.industries", "weights must add up to more than zero")
+	}
+}
@@ -0,0 +1,88 @@
+package data
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+// TestValidateCheckedIn keeps data/data.json free of problems.
+func TestValidateCheckedIn(t *testing.T) {
+	raw, err := os.ReadFile("../../data/data.json")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	d, err := Load(raw, nil, false)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if err := d.Validate(); err != nil {
+		t.Errorf("expected data/data.json to validate, got:\n%v", err)
+	}
+}
+
+// TestValidateReportsPaths checks each problem points at the offending field.
+func TestValidateReportsPaths(t *testing.T) {
+	d := Data{
+		Version: 0,
+		Names: Names{
+			Titles:           Titles{Male: []string{"Mr"}, Female: []string{"Ms"}},
+			MaleFirstNames:   []string{"Juan", "李"},
+			FemaleFirstNames: nil,
+			LastNames:        []string{"Santos", "Santos"},
+		},
+		Locations: []Location{{
+			Region: "Central Visayas",
+			Provinces: []Province{{Name: "Cebu", Cities: []City{
+				{Name: "Moalboal", Kind: KindMunicipality, Zipcode: "603", AreaCode: "032",
+					Latitude: 9.94, Longitude: 123.39, RadiusKm: 4, Barangays: []Barangay{{Name: "Basdiot"}, {Name: "Basdiot"}}},
+				{Name: "Moalboal", Kind: KindMunicipality, Zipcode: "6032", AreaCode: "032",
+					Latitude: 9.94, Longitude: 123.39, RadiusKm: 4},
+			}}},
+		}},
+		MobileProviders: MobileProviders{GlobeTM: []string{"917"}},
+		EmailDomains:    []EmailDomain{{Domain: "gmail.com", Weight: 1}},
+		Employment: Employment{
+			CompanyWords: []string{"Mabuhay"},
+			Industries: []Industry{{
+				Name: "Retail", Weight: 1,
+				Employers: []string{"{surname} Enterprises"},
+				Jobs:      []Job{{Title: "Cashier", SalaryMin: 16000, SalaryMax: 12000}},
+			}},
+		},
+	}
+
+	err := d.Validate()
+	if err == nil {
+		t.Fatalf("expected problems, got none")
+	}
+
+	want := []string{
+		"version",
+		"ref_year",
+		"names.male_first_names[1]",
+		"names.female_first_names",
+		"names.last_names[1]",
+		"locations[0].provinces[0].cities[0].zipcode",
+		"locations[0].provinces[0].cities[0].barangays[1].name",
+		"locations[0].provinces[0].cities[1].name",
+		"locations[0].provinces[0].cities[1].barangays",
+		"mobile_providers.globe_tm[0]",
+		"employment.industries[0].employers[0]",
+		"employment.industries[0].jobs[0]",
+	}
+	got := map[string]bool{}
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var p Problem
+		if errors.As(e, &p) {
+			got[p.Path] = true
+		}
+	}
+	for _, path := range want {
+		if !got[path] {
+			t.Errorf("expected a problem at %s, got:\n%v", path, err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package data
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// legacyRefYear is the RefYear of frozen versions from before ref_year,
+// all released in 2026.
+const legacyRefYear = 2026
+
+// LoadVersions reads the frozen datasets in fsys, one <version>.json per
+// earlier release of data.json (e.g. "1.json"). Each file must carry the
+// version its name says, and that version must be older than latest.
+// The result is sorted by version.
+func LoadVersions(fsys fs.FS, latest int) ([]*Data, error) {
+	matches, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []*Data
+	for _, name := range matches {
+		want, err := strconv.Atoi(strings.TrimSuffix(path.Base(name), ".json"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: file name must be the version number", name)
+		}
+
+		raw, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		var d Data
+		if err := json.Unmarshal(raw, &d); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if d.Version != want {
+			return nil, fmt.Errorf("%s: has version %d", name, d.Version)
+		}
+		if d.Version >= latest {
+			return nil, fmt.Errorf("%s: version %d is not older than the current %d", name, d.Version, latest)
+		}
+		if d.RefYear == 0 {
+			d.RefYear = legacyRefYear
+		}
+		if err := nameCollegeRegions(raw, &d); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		sum := sha256.Sum256(raw)
+		d.Hash = hex.EncodeToString(sum[:])[:hashLength]
+		versions = append(versions, &d)
+	}
+
+	slices.SortFunc(versions, func(a, b *Data) int { return a.Version - b.Version })
+
+	return versions, nil
+}
+
+// nameCollegeRegions points the colleges of d at their region by name.
+// Version 2 named it by a code it also gave each region in raw; those
+// codes were made up and aren't served, but still match the colleges.
+func nameCollegeRegions(raw []byte, d *Data) error {
+	var coded struct {
+		Locations []struct {
+			Region string `json:"region"`
+			Code   string `json:"psgc"`
+		} `json:"locations"`
+	}
+	if err := json.Unmarshal(raw, &coded); err != nil {
+		return err
+	}
+
+	regions := make(map[string]string, len(coded.Locations))
+	for _, l := range coded.Locations {
+		if l.Code != "" {
+			regions[l.Code] = l.Region
+		}
+	}
+	for i, c := range d.Education.Colleges {
+		if region, ok := regions[c.Region]; ok {
+			d.Education.Colleges[i].Region = region
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package data
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+// TestLoadVersions reads frozen datasets in version order.
+func TestLoadVersions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"2.json":    {Data: []byte(`{"version": 2, "names": {"last_names": ["Santos", "Reyes"]}}`)},
+		"1.json":    {Data: []byte(`{"version": 1, "names": {"last_names": ["Santos"]}}`)},
+		"README.md": {Data: []byte("not a dataset")},
+	}
+
+	versions, err := LoadVersions(fsys, 3)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(versions) != 2 || versions[0].Version != 1 || versions[1].Version != 2 {
+		t.Fatalf("expected versions 1 and 2, got: %v", versions)
+	}
+	if versions[0].Hash == "" || versions[0].Hash == versions[1].Hash {
+		t.Errorf("expected distinct hashes, got: %q and %q", versions[0].Hash, versions[1].Hash)
+	}
+}
+
+// TestLoadVersionsCollegeRegions matches colleges named by a region code
+// to the region's name, and doesn't serve the code.
+func TestLoadVersionsCollegeRegions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"2.json": {Data: []byte(`{
+			"version": 2,
+			"locations": [{ "region": "Caraga", "psgc": "1600000000" }],
+			"education": { "colleges": [
+				{ "name": "Caraga State University", "region": "1600000000" },
+				{ "name": "Father Saturnino Urios University", "region": "Caraga" }
+			] }
+		}`)},
+	}
+
+	versions, err := LoadVersions(fsys, 3)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	for _, c := range versions[0].Education.Colleges {
+		if c.Region != "Caraga" {
+			t.Errorf("expected %s in Caraga, got %q", c.Name, c.Region)
+		}
+	}
+}
+
+// TestLoadVersionsRejects catches misnamed and too-new frozen files.
+func TestLoadVersionsRejects(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"name mismatch": {"1.json": {Data: []byte(`{"version": 2}`)}},
+		"not a number":  {"old.json": {Data: []byte(`{"version": 1}`)}},
+		"not older":     {"3.json": {Data: []byte(`{"version": 3}`)}},
+	}
+
+	for name, fsys := range tests {
+		if _, err := LoadVersions(fsys, 3); err == nil {
+			t.Errorf("%s: expected an error, got none", name)
+		}
+	}
+}
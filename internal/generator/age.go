@@ -0,0 +1,63 @@
+package generator
+
+import (
+	mathrand "math/rand/v2"
+	"time"
+)
+
+// ageAt returns the number of whole years from birth to at.
+// It also serves for "years since registration". Someone born on February 29
+// has their birthday on March 1 in other years.
+func ageAt(birth, at time.Time) int {
+	age := at.Year() - birth.Year()
+	if at.Before(birth.AddDate(age, 0, 0)) {
+		age--
+	}
+	return age
+}
+
+// generateRegistration picks a sign-up time uniformly between the later of
+// the 18th birthday and maxYears before ref, and ref itself.
+// ref is never in the future, so neither is the result.
+func generateRegistration(dob, ref time.Time, maxYears int, rng *mathrand.Rand) time.Time {
+	earliest := dob.AddDate(minAge, 0, 0)
+	if limit := ref.AddDate(-maxYears, 0, 0); limit.After(earliest) {
+		earliest = limit
+	}
+	return timeBetween(earliest, ref, rng)
+}
+
+// timeBetween picks a whole second uniformly in [earliest, latest).
+// It returns earliest when the two are less than a second apart.
+func timeBetween(earliest, latest time.Time, rng *mathrand.Rand) time.Time {
+	span := latest.Sub(earliest)
+	if span < time.Second {
+		return earliest
+	}
+	return earliest.Add(time.Duration(rng.Int64N(int64(span/time.Second))) * time.Second)
+}
+
+// generateDOB draws a birthday uniformly from every day that makes someone
+// exactly age years old at ref.
+func generateDOB(age int, ref time.Time, rng *mathrand.Rand) time.Time {
+	first, last := birthWindow(age, ref)
+	days := int(last.Sub(first).Hours()/24) + 1
+	return first.AddDate(0, 0, rng.IntN(days))
+}
+
+// birthWindow returns the first and last birthdays (at midnight UTC) that
+// make someone age years old at ref. Stepping a day at a time around the
+// naive bounds handles leap days without special cases.
+func birthWindow(age int, ref time.Time) (first, last time.Time) {
+	first = ref.AddDate(-age-1, 0, -1)
+	for ageAt(first, ref) > age {
+		first = first.AddDate(0, 0, 1)
+	}
+
+	last = ref.AddDate(-age, 0, 1)
+	for ageAt(last, ref) < age {
+		last = last.AddDate(0, 0, -1)
+	}
+
+	return first, last
+}
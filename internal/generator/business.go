@@ -0,0 +1,224 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mrjxtr/rpug/internal/data"
+)
+
+// BusinessType is how a business is organized, which decides whether it
+// registers with the DTI or the SEC.
+type BusinessType string
+
+const (
+	BusinessSoleProprietorship BusinessType = "sole proprietorship"
+	BusinessPartnership        BusinessType = "partnership"
+	BusinessCorporation        BusinessType = "corporation"
+)
+
+const (
+	partnershipPercent = 15 // of SEC registrations; the rest are corporations
+	branchPercent      = 20 // businesses at a branch rather than the head office
+	maxBranches        = 20
+	maxBusinessYears   = 30 // registered at most this many years before ref
+	headOfficeBranch   = "00000"
+)
+
+// corporationSuffixes end a corporation's registered name.
+var corporationSuffixes = []string{", Inc.", " Corp.", " Corporation"}
+
+// ErrNoBusinesses is returned when the dataset, such as a frozen version
+// from before businesses, has nothing to draw businesses from.
+var ErrNoBusinesses = errors.New("no businesses in data version")
+
+// Business is a PH business at its registered address, and the Pinoy who
+// owns it.
+type Business struct {
+	Name         string       `json:"name"`
+	Type         BusinessType `json:"type"`
+	Industry     string       `json:"industry"`
+	Registration Registration `json:"registration"`
+	// TIN is the BIR taxpayer identification number and branch code,
+	// "123-456-789-00000"; the head office is branch 00000.
+	TIN      string   `json:"tin"`
+	Location Location `json:"location"`
+	Owner    Pinoy    `json:"owner"`
+}
+
+// Registration is the business's DTI business name registration, for sole
+// proprietorships, or its SEC registration, for partnerships and
+// corporations ("CS" or "PG", the year and a serial).
+type Registration struct {
+	Agency string `json:"agency"`
+	Number string `json:"number"`
+	Date   Date   `json:"date"`
+}
+
+type BusinessResponse struct {
+	Results *[]Business `json:"results"`
+	Info    Info        `json:"info"`
+}
+
+// GenerateBusinesses creates a BusinessResponse with n businesses.
+// Seeds, options and errors work as in Generate; options shape the owners.
+func (g *PinoyGenerator) GenerateBusinesses(
+	resParam int,
+	seedParam string,
+	opts Options,
+) (*BusinessResponse, error) {
+	d, seed, rng, err := g.begin(seedParam, &opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(d.Businesses.Industries) == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrNoBusinesses, d.Version)
+	}
+
+	b := g.newBatch(d, resParam, rng, opts)
+
+	businesses := make([]Business, resParam)
+	for i := range businesses {
+		biz, err := b.business()
+		if err != nil {
+			return nil, err
+		}
+		businesses[i] = biz
+	}
+
+	info, err := g.generateInfo(d, len(businesses), seed, opts.Lang)
+	if err != nil {
+		return &BusinessResponse{}, err
+	}
+
+	return &BusinessResponse{
+		Results: &businesses,
+		Info:    info,
+	}, nil
+}
+
+// business draws an owner living in a region, then a business of theirs
+// somewhere in the same region, registered after they turned 18.
+func (b *batch) business() (Business, error) {
+	rng := b.rng
+	region := b.drawRegion()
+
+	var owner Pinoy
+	if err := b.drawPerson(&owner); err != nil {
+		return Business{}, err
+	}
+	dob := owner.DOB.Date.Time
+
+	home := b.drawPlace(region)
+	b.fillAddress(&owner.Location, home)
+	if err := b.fillContacts(&owner, home.city, ""); err != nil {
+		return Business{}, err
+	}
+	// ? NOTE: Owners run their business rather than work for an employer, so
+	// ? they have no Employment and PhilHealth files them as self-earning
+	b.fillEducation(&owner, home)
+	b.fillFinance(&owner)
+	b.fillHealth(&owner)
+	b.fillSocial(&owner)
+	b.fillDocuments(&owner)
+	b.fillIdentity(&owner, b.drawIdentity(region), region)
+
+	pl := b.drawPlace(region)
+	ind := pickWeighted(b.d.Businesses.Industries, func(i data.BusinessIndustry) int { return i.Weight }, rng)
+	kind := BusinessSoleProprietorship
+	if rng.IntN(100) < ind.SECPercent {
+		kind = BusinessCorporation
+		if rng.IntN(100) < partnershipPercent {
+			kind = BusinessPartnership
+		}
+	}
+
+	name, err := b.businessName(ind, kind, &owner, pl.city)
+	if err != nil {
+		return Business{}, err
+	}
+
+	earliest := dob.AddDate(minAge, 0, 0)
+	if limit := b.ref.AddDate(-maxBusinessYears, 0, 0); limit.After(earliest) {
+		earliest = limit
+	}
+	registered := timeBetween(earliest, b.ref, rng).Truncate(24 * time.Hour)
+
+	reg := Registration{Agency: "DTI", Date: Date{Time: registered, Format: b.opts.DateFormat}}
+	switch kind {
+	case BusinessSoleProprietorship:
+		reg.Number = b.claimID(func() string { return fillDigits("#######", rng) })
+	default:
+		prefix := "CS"
+		if kind == BusinessPartnership {
+			prefix = "PG"
+		}
+		reg.Agency = "SEC"
+		reg.Number = b.claimID(func() string {
+			return fmt.Sprintf("%s%d%s", prefix, registered.Year(), fillDigits("#####", rng))
+		})
+	}
+
+	biz := Business{
+		Name:         name,
+		Type:         kind,
+		Industry:     ind.Name,
+		Registration: reg,
+		TIN:          b.claimID(func() string { return fillDigits("###-###-###-", rng) + b.drawBranch() }),
+		Owner:        owner,
+	}
+	b.fillAddress(&biz.Location, pl)
+
+	b.translate(&biz.Owner)
+	b.selectFields(&biz.Owner)
+
+	return biz, nil
+}
+
+// businessName fills a name template of ind for owner's business in city,
+// with the suffix its kind is registered under. Registered names are unique,
+// so it redraws while the name is taken in the batch.
+func (b *batch) businessName(ind data.BusinessIndustry, kind BusinessType, owner *Pinoy, city data.City) (string, error) {
+	rng := b.rng
+	words := b.d.Employment.CompanyWords
+	cityName, _, _ := strings.Cut(city.Name, " (")
+
+	for attempt := 0; ; attempt++ {
+		if attempt == maxUniqueAttempts {
+			return "", fmt.Errorf("%w: ran out of unique business names", ErrUniqueExhausted)
+		}
+
+		// ? NOTE: Fill only the placeholders the template uses, so each draws at most once
+		name := ind.Names[rng.IntN(len(ind.Names))]
+		if strings.Contains(name, "{word}") && len(words) > 0 {
+			name = strings.ReplaceAll(name, "{word}", words[rng.IntN(len(words))])
+		}
+		name = strings.NewReplacer(
+			"{first}", owner.Name.First,
+			"{last}", owner.Name.Last,
+			"{city}", cityName,
+		).Replace(name)
+
+		switch kind {
+		case BusinessCorporation:
+			name += corporationSuffixes[rng.IntN(len(corporationSuffixes))]
+		case BusinessPartnership:
+			name += " & Co."
+		}
+
+		if b.firms.claim(name) {
+			return name, nil
+		}
+	}
+}
+
+// drawBranch returns the head office branch code, or for some businesses
+// that of one of their branches.
+func (b *batch) drawBranch() string {
+	if b.rng.IntN(100) >= branchPercent {
+		return headOfficeBranch
+	}
+	return fmt.Sprintf("%05d", b.rng.IntN(maxBranches)+1)
+}
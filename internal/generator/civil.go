@@ -0,0 +1,59 @@
+package generator
+
+import mathrand "math/rand/v2"
+
+// CivilStatus is the civil status as written on PH government forms.
+type CivilStatus string
+
+const (
+	CivilSingle    CivilStatus = "single"
+	CivilMarried   CivilStatus = "married"
+	CivilWidowed   CivilStatus = "widowed"
+	CivilSeparated CivilStatus = "separated"
+	CivilAnnulled  CivilStatus = "annulled"
+)
+
+// civilStatusVersion is the first dataset version with civil status,
+// middle names and households. Older versions draw none of them, in their
+// original order, so their pinned seeds still give the same people, and
+// GenerateHouseholds refuses them.
+const civilStatusVersion = 2
+
+// husbandSurnamePercent is the share of married, widowed and separated women
+// who use their husband's surname. PH law makes it optional, most still do.
+const husbandSurnamePercent = 80
+
+type civilWeight struct {
+	status CivilStatus
+	weight int
+}
+
+// civilStatusBands weighs each status by age, loosely after the PSA census:
+// most are single until their late twenties, widowhood grows with age.
+var civilStatusBands = []struct {
+	maxAge  int // inclusive
+	weights []civilWeight
+}{
+	{24, []civilWeight{{CivilSingle, 88}, {CivilMarried, 11}, {CivilSeparated, 1}}},
+	{34, []civilWeight{{CivilSingle, 45}, {CivilMarried, 50}, {CivilSeparated, 3}, {CivilAnnulled, 1}, {CivilWidowed, 1}}},
+	{44, []civilWeight{{CivilSingle, 20}, {CivilMarried, 70}, {CivilSeparated, 5}, {CivilAnnulled, 2}, {CivilWidowed, 3}}},
+	{maxAge, []civilWeight{{CivilSingle, 12}, {CivilMarried, 70}, {CivilSeparated, 6}, {CivilAnnulled, 2}, {CivilWidowed, 10}}},
+}
+
+// generateCivilStatus draws a civil status weighted for age.
+func generateCivilStatus(age int, rng *mathrand.Rand) CivilStatus {
+	band := civilStatusBands[len(civilStatusBands)-1]
+	for _, b := range civilStatusBands {
+		if age <= b.maxAge {
+			band = b
+			break
+		}
+	}
+	return pickWeighted(band.weights, func(w civilWeight) int { return w.weight }, rng).status
+}
+
+// wasMarried reports whether s follows a marriage that still stands in
+// name: a woman keeps her husband's surname unless the marriage is annulled.
+func (s CivilStatus) wasMarried() bool {
+	return s == CivilMarried || s == CivilWidowed || s == CivilSeparated
+}
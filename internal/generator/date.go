@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DateFormat selects how Date fields are written.
+type DateFormat string
+
+const (
+	DateRFC3339 DateFormat = "rfc3339" // "1994-03-05T00:00:00Z"
+	DateUnix    DateFormat = "unix"    // 762825600, a JSON number
+	DateYMD     DateFormat = "ymd"     // "1994-03-05"
+	DateMDY     DateFormat = "mdy"     // "03/05/1994", as on PH forms
+	DateISOWeek DateFormat = "isoweek" // "1994-W09-6"
+)
+
+// DateFormats lists every supported DateFormat.
+var DateFormats = []DateFormat{DateRFC3339, DateUnix, DateYMD, DateMDY, DateISOWeek}
+
+// Date is a point in time that marshals in the requested Format,
+// so every date in a response follows ?date_format=.
+type Date struct {
+	Time   time.Time
+	Format DateFormat
+}
+
+// String writes the date in its Format; unix gives the decimal seconds.
+func (d Date) String() string {
+	switch d.Format {
+	case DateUnix:
+		return strconv.FormatInt(d.Time.Unix(), 10)
+	case DateYMD:
+		return d.Time.Format(time.DateOnly)
+	case DateMDY:
+		return d.Time.Format("01/02/2006")
+	case DateISOWeek:
+		year, week := d.Time.ISOWeek()
+		weekday := int(d.Time.Weekday())
+		if weekday == 0 {
+			weekday = 7 // ISO weeks run Monday (1) to Sunday (7)
+		}
+		return fmt.Sprintf("%04d-W%02d-%d", year, week, weekday)
+	default:
+		return d.Time.Format(time.RFC3339)
+	}
+}
+
+// MarshalJSON writes unix dates as numbers and the rest as strings.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.Format == DateUnix {
+		return strconv.AppendInt(nil, d.Time.Unix(), 10), nil
+	}
+	return json.Marshal(d.String())
+}
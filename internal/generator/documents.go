@@ -0,0 +1,235 @@
+package generator
+
+import (
+	"fmt"
+	mathrand "math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/mrjxtr/rpug/internal/data"
+)
+
+// LicenseType is the kind of LTO driver's license.
+type LicenseType string
+
+const (
+	LicenseNonProfessional LicenseType = "non-professional"
+	LicenseProfessional    LicenseType = "professional"
+)
+
+const (
+	licensePercent      = 40 // adults with a driver's license
+	professionalPercent = 15 // license holders who drive for a living
+	vehicleOwnerPercent = 35 // license holders for cars who own one
+	passportPercent     = 25 // adults with a passport; every OFW has one
+	cleanRecordPercent  = 70 // holders without violations
+
+	licenseValidYears  = 5  // a card runs to the holder's birthday this many years on
+	cleanLicenseYears  = 10 // or this many, for a clean record since tenYearLicenseStart
+	passportValidYears = 10 // for adults, since RA 10928
+	ltoOffices         = 40 // district office numbers under each letter
+	maxVehicleAge      = 20 // oldest model year, in years before ref
+	currentPlateYear   = 2014
+)
+
+// licenseExpiryVersion is the first dataset version whose licenses expire on
+// the last birthday within their validity. Older versions keep running them
+// to the birthday in their last year, up to a year longer, as released.
+const licenseExpiryVersion = 3
+
+// Plates have three letters and, from currentPlateYear, four digits;
+// older registrations kept their three.
+const (
+	currentPlateDigits = "####"
+	legacyPlateDigits  = "###"
+)
+
+var (
+	// tenYearLicenseStart is when LTO began issuing ten-year licenses to
+	// drivers with a clean record.
+	tenYearLicenseStart = time.Date(2021, time.October, 29, 0, 0, 0, 0, time.UTC)
+	// tenYearPassportStart is when DFA began issuing ten-year passports to
+	// adults; older ones ran five years and have all expired.
+	tenYearPassportStart = time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// plateLetters are the letters on LTO plates and license office codes,
+// without I, O and Q, which pass for 1 and 0.
+const plateLetters = "ABCDEFGHJKLMNPRSTUVWXYZ"
+
+// licenseCodes is a set of LTO DL codes a license is good for, e.g. A for
+// motorcycles, B for cars and light vehicles, C for trucks, D for buses.
+type licenseCodes struct {
+	codes  []string
+	weight int
+}
+
+var (
+	nonProfessionalCodes = []licenseCodes{
+		{[]string{"A"}, 25},
+		{[]string{"B"}, 35},
+		{[]string{"A", "B"}, 40},
+	}
+	professionalCodes = []licenseCodes{
+		{[]string{"B", "B1", "B2"}, 35},
+		{[]string{"A", "B", "B1", "B2"}, 30},
+		{[]string{"B", "B1", "B2", "C"}, 20},
+		{[]string{"B", "B1", "C", "CE"}, 8},
+		{[]string{"B", "B1", "D"}, 7},
+	}
+)
+
+// DriversLicense is an LTO driver's license. Number is
+// "<office>-<year first licensed>-<serial>", e.g. "N01-12-345678", DLCodes
+// are the vehicles it is good for (see licenseCodes), and the card expires
+// on the holder's birthday.
+type DriversLicense struct {
+	Number     string      `json:"number"`
+	Type       LicenseType `json:"type"`
+	DLCodes    []string    `json:"dl_codes"`
+	IssueDate  Date        `json:"issue_date"`
+	ExpiryDate Date        `json:"expiry_date"`
+}
+
+// Passport is a DFA ePassport, "P" + 7 digits + a letter.
+type Passport struct {
+	Number     string `json:"number"`
+	IssueDate  Date   `json:"issue_date"`
+	ExpiryDate Date   `json:"expiry_date"`
+}
+
+// Vehicle is a car the person owns. Plate is in the format LTO issued for
+// its model year.
+type Vehicle struct {
+	Make  string `json:"make"`
+	Model string `json:"model"`
+	Year  int    `json:"year"`
+	Plate string `json:"plate"`
+}
+
+// fillDocuments gives some adults a driver's license, car owners among
+// them a vehicle, and some a passport, all valid at ref. OFWs always have a
+// passport, issued before they were deployed. It runs after fillEmployment.
+func (b *batch) fillDocuments(p *Pinoy) {
+	if len(b.d.Vehicles) == 0 {
+		return
+	}
+	rng := b.rng
+	adult := p.DOB.Date.Time.AddDate(minAge, 0, 0)
+
+	if rng.IntN(100) < licensePercent {
+		b.fillLicense(p, adult)
+		if slices.Contains(p.DriversLicense.DLCodes, "B") && rng.IntN(100) < vehicleOwnerPercent {
+			b.fillVehicle(p)
+		}
+	}
+
+	ofw := p.Overseas.Country != ""
+	if ofw || rng.IntN(100) < passportPercent {
+		latest := b.ref
+		if ofw {
+			latest = p.Overseas.Contract.DeploymentDate.Time
+		}
+		earliest := b.ref.AddDate(-passportValidYears, 0, 1)
+		for _, t := range []time.Time{adult, tenYearPassportStart} {
+			if t.After(earliest) {
+				earliest = t
+			}
+		}
+		issued := timeBetween(earliest, latest, rng).Truncate(24 * time.Hour)
+		p.Passport = Passport{
+			Number: b.claimID(func() string {
+				return fmt.Sprintf("P%s%c", fillDigits("#######", rng), 'A'+rng.IntN(26))
+			}),
+			IssueDate:  Date{Time: issued, Format: b.opts.DateFormat},
+			ExpiryDate: Date{Time: issued.AddDate(passportValidYears, 0, -1), Format: b.opts.DateFormat},
+		}
+	}
+}
+
+// fillLicense licenses p some time after adult, with the current card
+// issued recently enough to still be valid at ref.
+func (b *batch) fillLicense(p *Pinoy, adult time.Time) {
+	rng := b.rng
+
+	licenseType, classes := LicenseNonProfessional, nonProfessionalCodes
+	if rng.IntN(100) < professionalPercent {
+		licenseType, classes = LicenseProfessional, professionalCodes
+	}
+	codes := pickWeighted(classes, func(c licenseCodes) int { return c.weight }, rng).codes
+
+	first := timeBetween(adult, b.ref, rng)
+	// ? NOTE: A card can expire up to a year short of its term, so one issued
+	// ? a year less than licenseValidYears ago is the oldest still valid at ref
+	earliest := b.ref.AddDate(-(licenseValidYears - 1), 0, 0)
+	if b.d.Version < licenseExpiryVersion {
+		earliest = b.ref.AddDate(-licenseValidYears, 0, 0)
+	}
+	if first.After(earliest) {
+		earliest = first
+	}
+	issued := timeBetween(earliest, b.ref, rng).Truncate(24 * time.Hour)
+
+	// ? NOTE: The card runs to the last birthday before its term is up
+	years := licenseValidYears
+	if !issued.Before(tenYearLicenseStart) && rng.IntN(100) < cleanRecordPercent {
+		years = cleanLicenseYears
+	}
+	dob := p.DOB.Date.Time
+	expires := time.Date(issued.Year()+years, dob.Month(), dob.Day(), 0, 0, 0, 0, time.UTC)
+	if b.d.Version >= licenseExpiryVersion && expires.After(issued.AddDate(years, 0, 0)) {
+		expires = time.Date(issued.Year()+years-1, dob.Month(), dob.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	p.DriversLicense = DriversLicense{
+		Number: b.claimID(func() string {
+			return fmt.Sprintf("%c%02d-%02d-%s",
+				plateLetters[rng.IntN(len(plateLetters))], rng.IntN(ltoOffices)+1, first.Year()%100, fillDigits("######", rng))
+		}),
+		Type:       licenseType,
+		DLCodes:    slices.Clone(codes),
+		IssueDate:  Date{Time: issued, Format: b.opts.DateFormat},
+		ExpiryDate: Date{Time: expires, Format: b.opts.DateFormat},
+	}
+}
+
+// fillVehicle gives p a weighted car model from the last maxVehicleAge
+// model years it was sold in, with a plate of that year's format.
+func (b *batch) fillVehicle(p *Pinoy) {
+	rng := b.rng
+
+	v := pickWeighted(b.d.Vehicles, func(v data.Vehicle) int { return v.Weight }, rng)
+	latest := b.ref.Year()
+	earliest := min(max(v.Since, latest-maxVehicleAge), latest)
+	year := earliest + rng.IntN(latest-earliest+1)
+
+	digits := legacyPlateDigits
+	if year >= currentPlateYear {
+		digits = currentPlateDigits
+	}
+	p.Vehicle = Vehicle{
+		Make:  v.Make,
+		Model: v.Model,
+		Year:  year,
+		Plate: b.claimID(func() string { return generatePlate(digits, rng) }),
+	}
+}
+
+// generatePlate writes three letters and digits, e.g. "NBC 1234".
+func generatePlate(digits string, rng *mathrand.Rand) string {
+	letters := make([]byte, 3)
+	for i := range letters {
+		letters[i] = plateLetters[rng.IntN(len(plateLetters))]
+	}
+	return string(letters) + " " + fillDigits(digits, rng)
+}
+
+// claimID draws with generate until it returns a number not yet handed out
+// in the batch. ID spaces are large enough that this ends in a draw or two.
+func (b *batch) claimID(generate func() string) string {
+	id := generate()
+	for !b.ids.claim(id) {
+		id = generate()
+	}
+	return id
+}
@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mrjxtr/rpug/internal/data"
+)
+
+// Attainment is the highest level of school someone finished.
+type Attainment string
+
+const (
+	AttainmentElementary Attainment = "elementary"
+	// AttainmentJuniorHigh is Grade 10 under K-12, for those still in senior high.
+	AttainmentJuniorHigh Attainment = "junior high school"
+	AttainmentHighSchool Attainment = "high school"
+	AttainmentCollege    Attainment = "college"
+)
+
+const (
+	// lastFourYearHighSchoolClass is the last class to finish high school in
+	// four years. From 2016, K-12 added Grades 11 and 12 (senior high school).
+	lastFourYearHighSchoolClass = 2015
+	manilaCollegePercent        = 15 // share who go to college in Metro Manila instead of their region
+)
+
+type attainmentWeight struct {
+	attainment Attainment
+	weight     int
+}
+
+// attainmentBands weighs each attainment by age, loosely after the PSA
+// census: younger Filipinos stayed in school longer.
+var attainmentBands = []struct {
+	maxAge  int // inclusive
+	weights []attainmentWeight
+}{
+	{24, []attainmentWeight{{AttainmentElementary, 10}, {AttainmentHighSchool, 58}, {AttainmentCollege, 32}}},
+	{34, []attainmentWeight{{AttainmentElementary, 14}, {AttainmentHighSchool, 48}, {AttainmentCollege, 38}}},
+	{49, []attainmentWeight{{AttainmentElementary, 22}, {AttainmentHighSchool, 46}, {AttainmentCollege, 32}}},
+	{maxAge, []attainmentWeight{{AttainmentElementary, 38}, {AttainmentHighSchool, 40}, {AttainmentCollege, 22}}},
+}
+
+// Education is the highest attainment, where it was finished and when.
+// Course is the degree for college graduates and the strand for K-12
+// senior high graduates.
+type Education struct {
+	Attainment     Attainment `json:"attainment"`
+	School         string     `json:"school"`
+	Course         string     `json:"course,omitempty"`
+	GraduationYear int        `json:"graduation_year"`
+}
+
+// fillEducation gives an adult an attainment weighted for age, with a
+// graduation year that follows from their DOB. Someone whose class hasn't
+// graduated yet by ref gets the level below.
+func (b *batch) fillEducation(p *Pinoy, pl place) {
+	ed := b.d.Education
+	if len(ed.Colleges) == 0 {
+		return
+	}
+	rng := b.rng
+
+	band := attainmentBands[len(attainmentBands)-1]
+	for _, ab := range attainmentBands {
+		if p.DOB.Age <= ab.maxAge {
+			band = ab
+			break
+		}
+	}
+	attainment := pickWeighted(band.weights, func(w attainmentWeight) int { return w.weight }, rng).attainment
+
+	// ? NOTE: Grade 1 starts in June for children who are 6 by August 31; each
+	// ? level ends in March, so a class of ref's year hasn't graduated yet
+	dob := p.DOB.Date.Time
+	grade1 := dob.Year() + 6
+	if dob.Month() > time.August {
+		grade1++
+	}
+	last := b.ref.Year() - 1
+	juniorHigh, highSchool := grade1+10, grade1+10
+	k12 := juniorHigh > lastFourYearHighSchoolClass
+	if k12 {
+		highSchool += 2
+	}
+
+	if attainment == AttainmentCollege {
+		course := pickWeighted(ed.Courses, func(c data.Course) int { return c.Weight }, rng)
+		if year := highSchool + course.Years; year <= last {
+			p.Education = Education{Attainment: AttainmentCollege, School: b.drawCollege(pl.region), Course: course.Name, GraduationYear: year}
+			return
+		}
+		attainment = AttainmentHighSchool
+	}
+
+	if attainment == AttainmentHighSchool {
+		school := fillSchoolName(ed.HighSchools[rng.IntN(len(ed.HighSchools))], pl)
+		switch {
+		case highSchool <= last && k12:
+			p.Education = Education{Attainment: AttainmentHighSchool, School: school, Course: ed.Strands[rng.IntN(len(ed.Strands))], GraduationYear: highSchool}
+			return
+		case highSchool <= last:
+			p.Education = Education{Attainment: AttainmentHighSchool, School: school, GraduationYear: highSchool}
+			return
+		case juniorHigh <= last:
+			p.Education = Education{Attainment: AttainmentJuniorHigh, School: school, GraduationYear: juniorHigh}
+			return
+		}
+	}
+
+	school := fillSchoolName(ed.ElementarySchools[rng.IntN(len(ed.ElementarySchools))], pl)
+	p.Education = Education{Attainment: AttainmentElementary, School: school, GraduationYear: grade1 + 6}
+}
+
+// drawCollege picks a college in region, or now and then one in Metro
+// Manila. Regions without colleges draw from the whole list.
+func (b *batch) drawCollege(region data.Location) string {
+	colleges := b.d.Education.Colleges
+	want := region.Region
+	if b.rng.IntN(100) < manilaCollegePercent {
+		want = ncrRegion
+	}
+
+	var near []data.College
+	for _, c := range colleges {
+		if c.Region == want {
+			near = append(near, c)
+		}
+	}
+	if len(near) == 0 {
+		near = colleges
+	}
+	return near[b.rng.IntN(len(near))].Name
+}
+
+// fillSchoolName fills the {city} and {barangay} of a school template.
+func fillSchoolName(tmpl string, pl place) string {
+	cityName, _, _ := strings.Cut(pl.city.Name, " (")
+	return strings.NewReplacer("{city}", cityName, "{barangay}", pl.barangay.Name).Replace(tmpl)
+}
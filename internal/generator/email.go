@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"fmt"
+	mathrand "math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/mrjxtr/rpug/internal/data"
+)
+
+// emailPatterns build the local-part of an address from a slugged first
+// and last name. Each mirrors a habit seen in real PH mailboxes.
+var emailPatterns = []func(first, last string, dob time.Time, rng *mathrand.Rand) string{
+	// juan.delacruz
+	func(first, last string, _ time.Time, _ *mathrand.Rand) string {
+		return first + "." + last
+	},
+	// jdelacruz
+	func(first, last string, _ time.Time, _ *mathrand.Rand) string {
+		return first[:min(len(first), 1)] + last
+	},
+	// juan.dc92
+	func(first, last string, dob time.Time, _ *mathrand.Rand) string {
+		return fmt.Sprintf("%s.%s%02d", first, initials(last), dob.Year()%100)
+	},
+	// juan_delacruz
+	func(first, last string, _ time.Time, _ *mathrand.Rand) string {
+		return first + "_" + last
+	},
+	// juandelacruz92
+	func(first, last string, dob time.Time, _ *mathrand.Rand) string {
+		return fmt.Sprintf("%s%s%02d", first, last, dob.Year()%100)
+	},
+	// juan.delacruz17
+	func(first, last string, _ time.Time, rng *mathrand.Rand) string {
+		return fmt.Sprintf("%s.%s%02d", first, last, rng.IntN(100))
+	},
+}
+
+// emailGenerator hands out addresses that are unique within one response.
+type emailGenerator struct {
+	domains  []data.EmailDomain
+	override string
+	seen     uniqueSet
+}
+
+// newEmailGenerator creates an emailGenerator for a batch of n records.
+// A non-empty override replaces the weighted domain list entirely.
+func newEmailGenerator(domains []data.EmailDomain, override string, n int) *emailGenerator {
+	return &emailGenerator{
+		domains:  domains,
+		override: override,
+		seen:     make(uniqueSet, n),
+	}
+}
+
+// generate builds an address for the given name and DOB.
+// On collision a counter is appended to the local-part until it is unused.
+func (e *emailGenerator) generate(first, last string, dob time.Time, rng *mathrand.Rand) string {
+	domain := e.override
+	if domain == "" {
+		domain = pickWeighted(e.domains, func(d data.EmailDomain) int { return d.Weight }, rng).Domain
+	}
+
+	pattern := emailPatterns[rng.IntN(len(emailPatterns))]
+	f, l := slugNames(first, last)
+	local := pattern(f, l, dob, rng)
+
+	return e.seen.claimNumbered(func(suffix string) string {
+		return local + suffix + "@" + domain
+	})
+}
+
+// slugName lowercases a name and keeps only ASCII letters and digits.
+// "Dela Cruz" becomes "delacruz" and "Peñaflor" becomes "penaflor".
+func slugName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		case r == 'ñ' || r == 'Ñ':
+			return 'n'
+		default:
+			return -1
+		}
+	}, name)
+}
+
+// slugNames slugs first and last for emailPatterns. Validate rejects names
+// that slug to nothing, but an unvalidated dataset can have one; it takes
+// the other name's slug so no pattern starts with a separator.
+func slugNames(first, last string) (string, string) {
+	f, l := slugName(first), slugName(last)
+	if f == "" {
+		f = l
+	}
+	if l == "" {
+		l = f
+	}
+	return f, l
+}
+
+// initials returns the lowercased first letter of each word in name.
+// "Dela Cruz" becomes "dc".
+func initials(name string) string {
+	var b strings.Builder
+	for _, word := range strings.Fields(name) {
+		if s := slugName(word); s != "" {
+			b.WriteByte(s[0])
+		}
+	}
+	return b.String()
+}
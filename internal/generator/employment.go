@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mrjxtr/rpug/internal/data"
+)
+
+const (
+	maxTenureYears = 15  // employment starts at most this many years before the reference date
+	salaryStep     = 500 // salaries are whole multiples of this, as payslips round them
+)
+
+// Employment is where and as what an adult works. Salary is per month, in PHP.
+type Employment struct {
+	Employer      string `json:"employer"`
+	Industry      string `json:"industry"`
+	JobTitle      string `json:"job_title"`
+	MonthlySalary int    `json:"monthly_salary"`
+	Currency      string `json:"currency"`
+	StartDate     Date   `json:"start_date"`
+}
+
+// fillEmployment gives an adult an employer, job and salary, and a start
+// date after their 18th birthday and graduation. Jobs that need a degree go
+// only to college graduates; it runs after fillEducation. Someone in an
+// overseas industry is an OFW and also gets an Overseas block.
+func (b *batch) fillEmployment(p *Pinoy, city data.City) {
+	emp := b.d.Employment
+	if len(emp.Industries) == 0 {
+		return
+	}
+	rng := b.rng
+
+	ind := pickWeighted(b.industries(), func(i data.Industry) int { return i.Weight }, rng)
+	jobs := ind.Jobs
+	if p.Education.Attainment != AttainmentCollege {
+		// ? NOTE: Keep every job if none is open to non-graduates, rather than none
+		open := slices.DeleteFunc(slices.Clone(jobs), func(j data.Job) bool { return j.RequiresDegree })
+		if len(open) > 0 {
+			jobs = open
+		}
+	}
+	job := jobs[rng.IntN(len(jobs))]
+
+	// ? NOTE: Fill only the placeholders the template uses, so each draws at most once
+	employer := ind.Employers[rng.IntN(len(ind.Employers))]
+	if strings.Contains(employer, "{word}") && len(emp.CompanyWords) > 0 {
+		employer = strings.ReplaceAll(employer, "{word}", emp.CompanyWords[rng.IntN(len(emp.CompanyWords))])
+	}
+	if strings.Contains(employer, "{last}") {
+		employer = strings.ReplaceAll(employer, "{last}", b.d.Names.LastNames[rng.IntN(len(b.d.Names.LastNames))])
+	}
+	cityName, _, _ := strings.Cut(city.Name, " (")
+	employer = strings.ReplaceAll(employer, "{city}", cityName)
+
+	salary := job.SalaryMin + salaryStep*rng.IntN((job.SalaryMax-job.SalaryMin)/salaryStep+1)
+
+	// ? NOTE: Started work as an adult who had left school, within the last
+	// ? maxTenureYears, on a whole day; classes graduate by April
+	earliest := p.DOB.Date.Time.AddDate(minAge, 0, 0)
+	if y := p.Education.GraduationYear; y > 0 {
+		if graduated := time.Date(y, time.April, 1, 0, 0, 0, 0, time.UTC); graduated.After(earliest) {
+			earliest = graduated
+		}
+	}
+	if limit := b.ref.AddDate(-maxTenureYears, 0, 0); limit.After(earliest) {
+		earliest = limit
+	}
+	start := timeBetween(earliest, b.ref, rng).Truncate(24 * time.Hour)
+
+	p.Employment = Employment{
+		Employer:      employer,
+		Industry:      ind.Name,
+		JobTitle:      job.Title,
+		MonthlySalary: salary,
+		Currency:      "PHP",
+		StartDate:     Date{Time: start, Format: b.opts.DateFormat},
+	}
+
+	if ind.Overseas {
+		b.fillOverseas(p)
+	}
+}
+
+// industries returns the industries to draw from. With OFWRatio set it first
+// decides whether the person is abroad, then keeps only the industries that
+// match; if none do, the dataset can't honour the ratio and all are kept.
+func (b *batch) industries() []data.Industry {
+	all := b.d.Employment.Industries
+	if b.opts.OFWRatio == nil {
+		return all
+	}
+
+	abroad := b.rng.Float64() < *b.opts.OFWRatio
+	matching := slices.DeleteFunc(slices.Clone(all), func(i data.Industry) bool {
+		return i.Overseas != abroad || i.Weight <= 0
+	})
+	if len(matching) == 0 {
+		return all
+	}
+	return matching
+}
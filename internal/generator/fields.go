@@ -0,0 +1,116 @@
+package generator
+
+import "slices"
+
+// Field is a top-level Pinoy field, named as in the JSON.
+type Field string
+
+const (
+	FieldName           Field = "name"
+	FieldDOB            Field = "dob"
+	FieldLocation       Field = "location"
+	FieldGender         Field = "gender"
+	FieldCivilStatus    Field = "civil_status"
+	FieldReligion       Field = "religion"
+	FieldEthnicity      Field = "ethnicity"
+	FieldLanguages      Field = "languages"
+	FieldPicture        Field = "picture"
+	FieldPhone          Field = "phone"
+	FieldCell           Field = "cell"
+	FieldLandline       Field = "landline"
+	FieldEmail          Field = "email"
+	FieldLogin          Field = "login"
+	FieldRegistered     Field = "registered"
+	FieldSocial         Field = "social"
+	FieldEducation      Field = "education"
+	FieldEmployment     Field = "employment"
+	FieldOverseas       Field = "overseas"
+	FieldFinance        Field = "finance"
+	FieldHealth         Field = "health"
+	FieldDriversLicense Field = "drivers_license"
+	FieldPassport       Field = "passport"
+	FieldVehicle        Field = "vehicle"
+)
+
+// Fields lists every field accepted in Options.Include and Options.Exclude,
+// in response order.
+var Fields = []Field{
+	FieldName, FieldDOB, FieldLocation, FieldGender, FieldCivilStatus,
+	FieldReligion, FieldEthnicity, FieldLanguages, FieldPicture,
+	FieldPhone, FieldCell, FieldLandline, FieldEmail, FieldLogin, FieldRegistered,
+	FieldSocial, FieldEducation, FieldEmployment, FieldOverseas, FieldFinance, FieldHealth,
+	FieldDriversLicense, FieldPassport, FieldVehicle,
+}
+
+// wants reports whether f is selected by Include and Exclude.
+func (o Options) wants(f Field) bool {
+	if len(o.Include) > 0 && !slices.Contains(o.Include, f) {
+		return false
+	}
+	return !slices.Contains(o.Exclude, f)
+}
+
+// selectFields clears the fields of p that weren't selected, so they are
+// left out of the JSON. It runs after every draw for the record.
+func (b *batch) selectFields(p *Pinoy) {
+	if len(b.opts.Include) == 0 && len(b.opts.Exclude) == 0 {
+		return
+	}
+
+	var zero Pinoy
+	for _, f := range Fields {
+		if b.opts.wants(f) {
+			continue
+		}
+		switch f {
+		case FieldName:
+			p.Name = zero.Name
+		case FieldDOB:
+			p.DOB = zero.DOB
+		case FieldLocation:
+			p.Location = zero.Location
+		case FieldGender:
+			p.Gender = zero.Gender
+		case FieldCivilStatus:
+			p.CivilStatus = zero.CivilStatus
+		case FieldReligion:
+			p.Religion = zero.Religion
+		case FieldEthnicity:
+			p.Ethnicity = zero.Ethnicity
+		case FieldLanguages:
+			p.Languages = zero.Languages
+		case FieldPicture:
+			p.Picture = zero.Picture
+		case FieldPhone:
+			p.Phone = zero.Phone
+		case FieldCell:
+			p.Cell = zero.Cell
+		case FieldLandline:
+			p.Landline = zero.Landline
+		case FieldEmail:
+			p.Email = zero.Email
+		case FieldLogin:
+			p.Login = zero.Login
+		case FieldRegistered:
+			p.Registered = zero.Registered
+		case FieldSocial:
+			p.Social = zero.Social
+		case FieldEducation:
+			p.Education = zero.Education
+		case FieldEmployment:
+			p.Employment = zero.Employment
+		case FieldOverseas:
+			p.Overseas = zero.Overseas
+		case FieldFinance:
+			p.Finance = zero.Finance
+		case FieldHealth:
+			p.Health = zero.Health
+		case FieldDriversLicense:
+			p.DriversLicense = zero.DriversLicense
+		case FieldPassport:
+			p.Passport = zero.Passport
+		case FieldVehicle:
+			p.Vehicle = zero.Vehicle
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"fmt"
+	mathrand "math/rand/v2"
+	"strings"
+
+	"github.com/mrjxtr/rpug/internal/data"
+)
+
+// testCard is a card number payment gateways (Stripe, Adyen, Braintree...)
+// publish for testing, which no issuer will ever give a cardholder.
+type testCard struct {
+	brand  string
+	number string
+}
+
+// testCards is the only place card numbers come from, used whole: random
+// digits after a real issuer prefix could make a real card.
+var testCards = []testCard{
+	{"Visa", "4242424242424242"},
+	{"Visa", "4111111111111111"},
+	{"Visa", "4012888888881881"},
+	{"Visa", "4000056655665556"},
+	{"Mastercard", "5555555555554444"},
+	{"Mastercard", "5105105105105100"},
+	{"Mastercard", "2223003122003222"},
+	{"American Express", "378282246310005"},
+	{"American Express", "371449635398431"},
+	{"American Express", "378734493671000"},
+	{"JCB", "3530111333300000"},
+	{"JCB", "3566002020360505"},
+}
+
+const (
+	cardValidYears = 5 // cards expire up to this many years after ref
+	amexBrand      = "American Express"
+)
+
+// Finance is an adult's bank account, e-wallet and payment card.
+type Finance struct {
+	Bank   BankAccount `json:"bank"`
+	Wallet Wallet      `json:"wallet"`
+	Card   Card        `json:"card"`
+}
+
+type BankAccount struct {
+	Name          string `json:"name"`
+	AccountNumber string `json:"account_number"`
+}
+
+// Wallet is an e-wallet account, bound to the person's Phone.
+type Wallet struct {
+	Provider     string `json:"provider"`
+	MobileNumber string `json:"mobile_number"`
+}
+
+// Card is a published test card with a random expiry and CVV. Expiry is "MM/YY".
+type Card struct {
+	Brand  string `json:"brand"`
+	Number string `json:"number"`
+	Expiry string `json:"expiry"`
+	CVV    string `json:"cvv"`
+}
+
+// fillFinance gives an adult a bank account, an e-wallet on their mobile
+// number and a test card. It runs after fillContacts.
+func (b *batch) fillFinance(p *Pinoy) {
+	fin := b.d.Finance
+	if len(fin.Banks) == 0 {
+		return
+	}
+	rng := b.rng
+
+	bank := pickWeighted(fin.Banks, func(bk data.Bank) int { return bk.Weight }, rng)
+	p.Finance.Bank = BankAccount{Name: bank.Name, AccountNumber: fillDigits(bank.AccountFormat, rng)}
+
+	wallet := pickWeighted(fin.Wallets, func(w data.Wallet) int { return w.Weight }, rng)
+	p.Finance.Wallet = Wallet{Provider: wallet.Name, MobileNumber: p.Phone}
+
+	c := testCards[rng.IntN(len(testCards))]
+	cvvDigits := 3
+	if c.brand == amexBrand {
+		cvvDigits = 4
+	}
+	p.Finance.Card = Card{
+		Brand:  c.brand,
+		Number: c.number,
+		Expiry: fmt.Sprintf("%02d/%02d", rng.IntN(12)+1, (b.ref.Year()+1+rng.IntN(cardValidYears))%100),
+		CVV:    fillDigits(strings.Repeat("#", cvvDigits), rng),
+	}
+}
+
+// fillDigits replaces each # in format with a random digit.
+func fillDigits(format string, rng *mathrand.Rand) string {
+	out := []byte(format)
+	for i, c := range out {
+		if c == '#' {
+			out[i] = byte('0' + rng.IntN(10))
+		}
+	}
+	return string(out)
+}
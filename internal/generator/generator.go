@@ -2,10 +2,11 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
 	mathrand "math/rand/v2"
-	"strings"
-	"time"
+	"slices"
+	"sync/atomic"
 
 	"github.com/mrjxtr/rpug/internal/config"
 	"github.com/mrjxtr/rpug/internal/data"
@@ -18,47 +19,100 @@ const (
 	maxRegistrationYears = 5
 )
 
+// ErrUnknownDataVersion is returned when Options.DataVersion names a dataset
+// that is neither the current one nor a frozen one.
+var ErrUnknownDataVersion = errors.New("unknown data version")
+
 // TODO: Populate more Pinoy data
 
+// Pinoy is one generated person. A block the dataset has no section for,
+// as in frozen versions from before it, is left empty without a single
+// draw, so pinned seeds keep giving the same people.
 type Pinoy struct {
 	Name struct {
-		Title string `json:"title"`
+		Title string `json:"title,omitempty"`
 		First string `json:"first"`
-		Last  string `json:"last"`
-	} `json:"name"`
+		// Middle is the mother's maiden surname, or for a woman using her
+		// husband's surname, her own maiden surname.
+		Middle string `json:"middle,omitempty"`
+		Last   string `json:"last"`
+	} `json:"name,omitzero"`
 	DOB struct {
-		Date string `json:"date"`
-		Age  int    `json:"age"`
-	} `json:"dob"`
-	Location struct {
-		// Street struct {
-		// 	Number int    `json:"number"`
-		// 	Name   string `json:"name"`
-		// } `json:"street"`
-		City    string `json:"city"`
-		Region  string `json:"region"`
-		Country string `json:"country"`
-		Zipcode string `json:"zipcode"`
-	} `json:"location"`
-	Gender string `json:"gender"`
-	Phone  string `json:"phone"`
-	Email  string `json:"email"`
-	// Login  struct {
-	// 	UUID     string `json:"uuid"`
-	// 	Username string `json:"username"`
-	// 	Password string `json:"password"`
-	// } `json:"login"`
+		Date      Date   `json:"date"`
+		Formatted string `json:"formatted"`
+		Age       int    `json:"age"`
+	} `json:"dob,omitzero"`
+	Location    Location    `json:"location,omitzero"`
+	Gender      string      `json:"gender,omitempty"`
+	CivilStatus CivilStatus `json:"civil_status,omitempty"`
+	Religion    string      `json:"religion,omitempty"`
+	Ethnicity   string      `json:"ethnicity,omitempty"`
+	// Languages are the ones spoken, mother tongue first.
+	Languages []string `json:"languages,omitempty"`
+	Picture   struct {
+		Large     string `json:"large"`
+		Medium    string `json:"medium"`
+		Thumbnail string `json:"thumbnail"`
+	} `json:"picture,omitzero"`
+	// Phone mirrors Cell so clients built before the cell/landline pair keep working.
+	Phone    string `json:"phone,omitempty"`
+	Cell     string `json:"cell,omitempty"`
+	Landline string `json:"landline,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Login    struct {
+		UUID     string `json:"uuid"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"login,omitzero"`
 	Registered struct {
-		Date string `json:"date"`
-		Age  int    `json:"age"`
-	} `json:"registered"`
+		Date      Date   `json:"date"`
+		Formatted string `json:"formatted"`
+		Age       int    `json:"age"`
+	} `json:"registered,omitzero"`
+	Social     Social     `json:"social,omitzero"`
+	Education  Education  `json:"education,omitzero"`
+	Employment Employment `json:"employment,omitzero"`
+	// Overseas is set for OFWs; Location stays their home address.
+	Overseas Overseas `json:"overseas,omitzero"`
+	Finance  Finance  `json:"finance,omitzero"`
+	Health   Health   `json:"health,omitzero"`
+
+	DriversLicense DriversLicense `json:"drivers_license,omitzero"`
+	Passport       Passport       `json:"passport,omitzero"`
+	Vehicle        Vehicle        `json:"vehicle,omitzero"`
+}
+
+// Location is a PH address down to the barangay.
+type Location struct {
+	// Street struct {
+	// 	Number int    `json:"number"`
+	// 	Name   string `json:"name"`
+	// } `json:"street"`
+	Barangay    string `json:"barangay"`
+	City        string `json:"city"`
+	Province    string `json:"province"`
+	Region      string `json:"region"`
+	Country     string `json:"country"`
+	Zipcode     string `json:"zipcode"`
+	Formatted   string `json:"formatted"`
+	Coordinates struct {
+		Latitude  string `json:"latitude"`
+		Longitude string `json:"longitude"`
+	} `json:"coordinates"`
+	Timezone struct {
+		Offset      string `json:"offset"`
+		Description string `json:"description"`
+	} `json:"timezone"`
 }
 
 type Info struct {
-	Seed    string `json:"seed"`
-	Results int    `json:"results"`
-	Page    int    `json:"page,omitempty"`
-	Version string `json:"version,omitempty"`
+	Seed        string `json:"seed"`
+	Results     int    `json:"results"`
+	Page        int    `json:"page,omitempty"`
+	Lang        Lang   `json:"lang,omitempty"`
+	Version     string `json:"version,omitempty"`
+	DataVersion int    `json:"data_version,omitempty"`
+	DataHash    string `json:"data_hash,omitempty"`
 }
 
 type PinoyResponse struct {
@@ -66,17 +120,74 @@ type PinoyResponse struct {
 	Info    Info     `json:"info"`
 }
 
+// Options tweaks how Generate builds each Pinoy.
+// The zero value uses the dataset defaults.
+type Options struct {
+	// EmailDomain, when set, replaces the weighted domain list for every email.
+	EmailDomain string
+	// Unique lists fields that must not repeat within the batch.
+	// Emails are always unique; the others are opt-in.
+	Unique []UniqueField
+	// DataVersion pins the dataset so a seed keeps reproducing the same
+	// people after data.json grows. Zero means the current dataset.
+	DataVersion int
+	// Lang picks the language of titles, gender and formatted fields.
+	// Zero means LangEnglish.
+	Lang Lang
+	// DateFormat is how every Date in the response is written.
+	// Zero means DateRFC3339.
+	DateFormat DateFormat
+	// MaxRegistrationYears is how far back registration dates can go.
+	// Zero means maxRegistrationYears.
+	MaxRegistrationYears int
+	// Include, when set, lists the only fields to return; Exclude lists
+	// fields to leave out. Either way every field is still drawn, so the
+	// same seed gives the same people whatever is selected.
+	Include, Exclude []Field
+	// OFWRatio, when set, is the share of adults working abroad, from 0 to 1.
+	// Nil leaves it to the industry weights in the dataset.
+	OFWRatio *float64
+}
+
+// isUnique reports whether f must stay unique within the batch.
+func (o Options) isUnique(f UniqueField) bool {
+	return slices.Contains(o.Unique, f)
+}
+
 type PinoyGenerator struct {
-	cfg  *config.Config
-	data *data.Data
+	cfg    *config.Config
+	data   atomic.Pointer[data.Data]
+	frozen map[int]*data.Data
 }
 
-// NewPinoyGenerator creates a new PinoyGenerator.
-func NewPinoyGenerator(cfg *config.Config, d *data.Data) *PinoyGenerator {
-	return &PinoyGenerator{
-		cfg:  cfg,
-		data: d,
+// NewPinoyGenerator creates a new PinoyGenerator serving d, plus any frozen
+// older datasets for requests that pin Options.DataVersion.
+func NewPinoyGenerator(cfg *config.Config, d *data.Data, frozen ...*data.Data) *PinoyGenerator {
+	g := &PinoyGenerator{cfg: cfg, frozen: make(map[int]*data.Data, len(frozen))}
+	g.data.Store(d)
+	for _, f := range frozen {
+		g.frozen[f.Version] = f
+	}
+	return g
+}
+
+// SetData atomically swaps the dataset used by later Generate calls.
+// Calls already running finish with the dataset they started with.
+// d must not be modified after it is handed over.
+func (g *PinoyGenerator) SetData(d *data.Data) {
+	g.data.Store(d)
+}
+
+// dataset returns the dataset for version, or the current one if version is 0.
+func (g *PinoyGenerator) dataset(version int) (*data.Data, error) {
+	d := g.data.Load()
+	if version == 0 || version == d.Version {
+		return d, nil
+	}
+	if f, ok := g.frozen[version]; ok {
+		return f, nil
 	}
+	return nil, fmt.Errorf("%w: %d", ErrUnknownDataVersion, version)
 }
 
 // Generate creates a PinoyResponse with n Pinoy records.
@@ -85,19 +196,18 @@ func NewPinoyGenerator(cfg *config.Config, d *data.Data) *PinoyGenerator {
 func (g *PinoyGenerator) Generate(
 	resParam int,
 	seedParam string,
+	opts Options,
 ) (*PinoyResponse, error) {
-	seed := seedParam
-	if seed == "" {
-		s, err := generateSeed()
-		if err != nil {
-			return nil, err
-		}
-		seed = s
+	d, seed, rng, err := g.begin(seedParam, &opts)
+	if err != nil {
+		return nil, err
 	}
-	rng := newRNGfromSeed(seed)
 
-	results := g.generatePinoys(resParam, rng)
-	info, err := g.generateInfo(results, seed)
+	results, err := g.generatePinoys(d, resParam, rng, opts)
+	if err != nil {
+		return nil, err
+	}
+	info, err := g.generateInfo(d, len(*results), seed, opts.Lang)
 	if err != nil {
 		return &PinoyResponse{}, err
 	}
@@ -108,92 +218,100 @@ func (g *PinoyGenerator) Generate(
 	}, nil
 }
 
-// generatePinoys creates n Pinoy records using the provided RNG.
-func (g *PinoyGenerator) generatePinoys(n int, rng *mathrand.Rand) *[]Pinoy {
-	pinoys := make([]Pinoy, n)
+// begin does the setup every endpoint shares: it picks the dataset, fills
+// in option defaults, and seeds the RNG from seedParam or a fresh seed.
+func (g *PinoyGenerator) begin(seedParam string, opts *Options) (*data.Data, string, *mathrand.Rand, error) {
+	// ? NOTE: Pick the dataset once so a reload mid-request can't mix two versions
+	d, err := g.dataset(opts.DataVersion)
+	if err != nil {
+		return nil, "", nil, err
+	}
 
-	globeTM := g.data.MobileProviders.GlobeTM
-	smartTntSun := g.data.MobileProviders.SmartTntSun
-	dito := g.data.MobileProviders.Dito
+	if opts.Lang == "" {
+		opts.Lang = LangEnglish
+	}
+	if opts.DateFormat == "" {
+		opts.DateFormat = DateRFC3339
+	}
+	if opts.MaxRegistrationYears == 0 {
+		opts.MaxRegistrationYears = maxRegistrationYears
+	}
 
-	providerList := append(append(globeTM, smartTntSun...), dito...)
+	seed := seedParam
+	if seed == "" {
+		s, err := generateSeed()
+		if err != nil {
+			return nil, "", nil, err
+		}
+		seed = s
+	}
+
+	return d, seed, newRNGfromSeed(seed), nil
+}
 
-	nameList := g.data.Names
-	lastNameList := nameList.LastNames
-	titleList := nameList.Titles
+// generatePinoys creates n Pinoy records using the provided RNG.
+// Returns ErrUniqueExhausted if opts.Unique cannot be met with the dataset.
+func (g *PinoyGenerator) generatePinoys(
+	d *data.Data,
+	n int,
+	rng *mathrand.Rand,
+	opts Options,
+) (*[]Pinoy, error) {
+	pinoys := make([]Pinoy, n)
 
-	locations := g.data.Locations
+	if opts.isUnique(UniqueName) {
+		names := d.Names
+		capacity := (len(names.MaleFirstNames) + len(names.FemaleFirstNames)) * len(names.LastNames)
+		if n > capacity {
+			return nil, fmt.Errorf(
+				"%w: %d results requested but only %d unique names exist",
+				ErrUniqueExhausted, n, capacity,
+			)
+		}
+	}
 
-	now := time.Now()
-	referenceDate := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	b := g.newBatch(d, n, rng, opts)
 
 	for i := range pinoys {
 		var p Pinoy
 
-		locationList := locations[rng.IntN(len(locations))]
-
-		// ? NOTE: Randomize gender based on seed
-		// ? Then generate the title, first name, and last name based on gender and seed
-		if rng.IntN(2) == 0 {
-			p.Gender = "male"
-			p.Name.Title = titleList.Male[rng.IntN(len(titleList.Male))]
-			p.Name.First = nameList.MaleFirstNames[rng.IntN(len(nameList.MaleFirstNames))]
-		} else {
-			p.Gender = "female"
-			p.Name.Title = titleList.Female[rng.IntN(len(titleList.Female))]
-			p.Name.First = nameList.FemaleFirstNames[rng.IntN(len(nameList.FemaleFirstNames))]
+		region := b.drawRegion()
+		if err := b.drawPerson(&p); err != nil {
+			return nil, err
 		}
-		p.Name.Last = lastNameList[rng.IntN(len(lastNameList))]
-
-		// ? NOTE: Generate a random Age from a configurable referenceDate
-		// ? Then we derive the DOB from the age based on seed
-		age := rng.IntN(maxAge-minAge) + minAge
-		dob := referenceDate.AddDate(-age, -rng.IntN(12), -rng.IntN(28))
-
-		p.DOB.Age = age
-		p.DOB.Date = dob.Format(time.RFC3339)
 
-		// TODO: Support more locations
-		// ? NOTE: Grab a random city based on seed
-		selectedCity := locationList.Cities[rng.IntN(len(locationList.Cities))]
+		pl := b.drawPlace(region)
+		b.fillAddress(&p.Location, pl)
 
-		p.Location.City = selectedCity.Name
-		p.Location.Region = locationList.Region
-		p.Location.Country = "Philippines"
-		p.Location.Zipcode = selectedCity.Zipcode
-
-		prefix := providerList[rng.IntN(len(providerList))]
-		suffix := fmt.Sprintf("%07d", rng.IntN(phoneSuffixMax))
-		p.Phone = prefix + suffix
-
-		// ? NOTE: Create a generic email from first and last name
-		// ? Remove whitespace since names can have multiple words (e.g., "Maria Clara", "Dela Cruz")
-		firstName := strings.ReplaceAll(p.Name.First, " ", "")
-		lastName := strings.ReplaceAll(p.Name.Last, " ", "")
-
-		p.Email = strings.ToLower(
-			fmt.Sprintf("%s.%s@gmail.com", firstName, lastName),
-		)
-
-		// ? NOTE: Generate random regestration age and date based on seed
-		regAge := rng.IntN(maxRegistrationYears)
-		regDage := now.AddDate(regAge, -rng.IntN(12), -rng.IntN(28))
+		if err := b.fillContacts(&p, pl.city, ""); err != nil {
+			return nil, err
+		}
+		b.fillEducation(&p, pl)
+		b.fillEmployment(&p, pl.city)
+		b.fillFinance(&p)
+		b.fillHealth(&p)
+		b.fillSocial(&p)
+		b.fillDocuments(&p)
+		b.fillIdentity(&p, b.drawIdentity(pl.region), pl.region)
 
-		p.Registered.Age = regAge
-		p.Registered.Date = regDage.Format(time.RFC3339)
+		b.translate(&p)
+		b.selectFields(&p)
 
 		pinoys[i] = p
 	}
 
-	return &pinoys
+	return &pinoys, nil
 }
 
-// generateInfo fills the response metadata based on n.
-func (g *PinoyGenerator) generateInfo(results *[]Pinoy, seed string) (Info, error) {
+// generateInfo fills the response metadata for n results.
+func (g *PinoyGenerator) generateInfo(d *data.Data, n int, seed string, lang Lang) (Info, error) {
 	return Info{
 		Seed:    seed,
-		Results: len(*results),
+		Results: n,
+		Lang:    lang,
 		// TODO: Implement pagination
-		Version: g.cfg.Version,
+		Version:     g.cfg.Version,
+		DataVersion: d.Version,
+		DataHash:    d.Hash,
 	}, nil
 }
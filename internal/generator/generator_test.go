@@ -2,7 +2,21 @@ package generator
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/mrjxtr/rpug/internal/config"
+	"github.com/mrjxtr/rpug/internal/data"
 )
 
 // TestSeedGenerator ensures generated seeds are 16-byte hex and decodable.
@@ -73,3 +87,1104 @@ func TestRNGUniqueness(t *testing.T) {
 		)
 	}
 }
+
+// TestLandlineAreaCode checks landlines follow the city's area code and local length.
+func TestLandlineAreaCode(t *testing.T) {
+	rng := newRNGfromSeed("8959bcbac47d82c434fd8f154dab3e04")
+
+	tests := []struct {
+		city   data.City
+		prefix string
+		length int
+	}{
+		{data.City{Name: "Makati", AreaCode: "02"}, "(02) 8", len("(02) 8123-4567")},
+		{data.City{Name: "Cebu City", AreaCode: "032"}, "(032) ", len("(032) 123-4567")},
+	}
+
+	for _, tt := range tests {
+		got := generateLandline(tt.city, rng)
+		if !strings.HasPrefix(got, tt.prefix) || len(got) != tt.length {
+			t.Errorf("%s: expected %q-prefixed landline of length %d, got: %q",
+				tt.city.Name, tt.prefix, tt.length, got)
+		}
+	}
+}
+
+// TestEmailUniqueness ensures namesakes in one batch never share an email.
+func TestEmailUniqueness(t *testing.T) {
+	rng := newRNGfromSeed("8959bcbac47d82c434fd8f154dab3e04")
+	emails := newEmailGenerator(nil, "example.test", 50)
+	dob := time.Date(1992, 5, 30, 0, 0, 0, 0, time.UTC)
+
+	seen := make(map[string]bool)
+	for range 50 {
+		email := emails.generate("Juan", "Dela Cruz", dob, rng)
+		if seen[email] {
+			t.Fatalf("duplicate email in batch: %q", email)
+		}
+		if !strings.HasSuffix(email, "@example.test") {
+			t.Errorf("expected override domain, got: %q", email)
+		}
+		seen[email] = true
+	}
+}
+
+// TestEmailUnsluggableName draws every pattern for a first name that slugs
+// to nothing, which only an unvalidated dataset can have, without panicking.
+func TestEmailUnsluggableName(t *testing.T) {
+	rng := newRNGfromSeed("8959bcbac47d82c434fd8f154dab3e04")
+	emails := newEmailGenerator(nil, "example.test", 50)
+	dob := time.Date(1992, 5, 30, 0, 0, 0, 0, time.UTC)
+
+	for range 50 {
+		email := emails.generate("李", "Santos", dob, rng)
+		if !strings.HasPrefix(email, "s") {
+			t.Errorf("expected the email to start with the last name, got: %q", email)
+		}
+	}
+}
+
+// testData is a tiny dataset small enough to exhaust on purpose.
+// testRefYear is the year tests count ages and dates from. It isn't the
+// checked-in ref_year, so tests only pass if batches read the year from
+// the dataset, never from the clock.
+const testRefYear = 2031
+
+// fixRef pins the RefYear of d to testRefYear and returns the ref that
+// batches over d count from.
+func fixRef(d *data.Data) time.Time {
+	d.RefYear = testRefYear
+	return time.Date(testRefYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func testData() *data.Data {
+	return &data.Data{
+		Version: 2,
+		RefYear: 2026,
+		Names: data.Names{
+			Titles:           data.Titles{Male: []string{"Mr"}, Female: []string{"Ms"}, Married: []string{"Mrs"}},
+			MaleFirstNames:   []string{"Juan", "Jose"},
+			FemaleFirstNames: []string{"Maria", "Ana"},
+			LastNames:        []string{"Santos", "Reyes"},
+		},
+		Locations: []data.Location{{
+			Region: "National Capital Region",
+			Provinces: []data.Province{{
+				Name: "NCR, Fourth District",
+				Cities: []data.City{{
+					Name:      "Makati",
+					Kind:      data.KindCity,
+					Zipcode:   "1200",
+					AreaCode:  "02",
+					Barangays: []data.Barangay{{Name: "Poblacion"}},
+				}},
+			}},
+		}},
+		MobileProviders: data.MobileProviders{GlobeTM: []string{"0917"}},
+		EmailDomains:    []data.EmailDomain{{Domain: "example.test", Weight: 1}},
+	}
+}
+
+// TestUniqueNames fills every name combination once, then refuses one more.
+func TestUniqueNames(t *testing.T) {
+	gen := NewPinoyGenerator(&config.Config{}, testData())
+	opts := Options{Unique: []UniqueField{UniqueName}}
+
+	resp, err := gen.Generate(8, "8959bcbac47d82c434fd8f154dab3e04", opts)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range *resp.Results {
+		name := p.Name.First + " " + p.Name.Last
+		if seen[name] {
+			t.Errorf("duplicate name in batch: %q", name)
+		}
+		seen[name] = true
+	}
+
+	if _, err := gen.Generate(9, "8959bcbac47d82c434fd8f154dab3e04", opts); !errors.Is(err, ErrUniqueExhausted) {
+		t.Errorf("expected ErrUniqueExhausted, got: %v", err)
+	}
+}
+
+// TestDataVersionPinning reproduces a seed on a frozen dataset after the
+// current one has grown.
+func TestDataVersionPinning(t *testing.T) {
+	const seed = "8959bcbac47d82c434fd8f154dab3e04"
+
+	v1 := testData()
+	v1.Version = 1
+	before, err := NewPinoyGenerator(&config.Config{}, v1).Generate(5, seed, Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	v2 := testData()
+	v2.Version = 2
+	v2.Names.LastNames = append(v2.Names.LastNames, "Cruz", "Bautista")
+	gen := NewPinoyGenerator(&config.Config{}, v2, v1)
+
+	after, err := gen.Generate(5, seed, Options{DataVersion: 1})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !reflect.DeepEqual(before, after) {
+		t.Errorf("expected data_version 1 to reproduce the original output")
+	}
+
+	latest, err := gen.Generate(5, seed, Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if latest.Info.DataVersion != 2 {
+		t.Errorf("expected the current dataset by default, got version %d", latest.Info.DataVersion)
+	}
+
+	if _, err := gen.Generate(5, seed, Options{DataVersion: 3}); !errors.Is(err, ErrUnknownDataVersion) {
+		t.Errorf("expected ErrUnknownDataVersion, got: %v", err)
+	}
+}
+
+// TestFrozenVersionsGolden checks that each frozen dataset still gives the
+// people recorded in testdata/golden_v<version>.json by the code that
+// served it as the latest version.
+// Regenerating these files defeats the point: a diff means pinned seeds broke.
+func TestFrozenVersionsGolden(t *testing.T) {
+	const (
+		seed    = "golden"
+		results = 10
+	)
+	current := checkedInData(t)
+	frozen, err := data.LoadVersions(os.DirFS("../../data/versions"), current.Version)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(frozen) == 0 {
+		t.Fatalf("expected frozen datasets in data/versions")
+	}
+	gen := NewPinoyGenerator(&config.Config{}, current, frozen...)
+
+	for _, f := range frozen {
+		raw, err := os.ReadFile(fmt.Sprintf("testdata/golden_v%d.json", f.Version))
+		if err != nil {
+			t.Fatalf("expected a golden file for data_version %d, got: %v", f.Version, err)
+		}
+		var want any
+		if err := json.Unmarshal(raw, &want); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		res, err := gen.Generate(results, seed, Options{DataVersion: f.Version})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		out, err := json.Marshal(res.Results)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		var got any
+		if err := json.Unmarshal(out, &got); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if !recorded(got, want) {
+			t.Errorf("expected data_version %d to reproduce testdata/golden_v%d.json, got:\n%s", f.Version, f.Version, out)
+		}
+	}
+}
+
+// recorded reports whether every field in want is in got with the same
+// value. Fields added to the output after want was recorded are ignored.
+func recorded(got, want any) bool {
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			return false
+		}
+		for k, v := range w {
+			if gv, ok := g[k]; !ok || !recorded(gv, v) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		g, ok := got.([]any)
+		if !ok || len(g) != len(w) {
+			return false
+		}
+		for i := range w {
+			if !recorded(g[i], w[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(got, want)
+	}
+}
+
+// TestFilipinoLocale translates labels without changing who is drawn.
+func TestFilipinoLocale(t *testing.T) {
+	const seed = "8959bcbac47d82c434fd8f154dab3e04"
+	gen := NewPinoyGenerator(&config.Config{}, testData())
+
+	en, err := gen.Generate(5, seed, Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	fil, err := gen.Generate(5, seed, Options{Lang: LangFilipino})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	for i, p := range *fil.Results {
+		e := (*en.Results)[i]
+		if p.Name.First != e.Name.First || p.Email != e.Email {
+			t.Errorf("expected the same person in both languages, got %q and %q", p.Email, e.Email)
+		}
+		if p.Name.Title != filipinoTitles[e.Name.Title] || p.Gender != filipinoGenders[e.Gender] {
+			t.Errorf("expected %s %s translated, got: %s %s", e.Name.Title, e.Gender, p.Name.Title, p.Gender)
+		}
+		if p.Location.Formatted != "Brgy. Poblacion, Lungsod ng Makati, Kalakhang Maynila 1200, Pilipinas" {
+			t.Errorf("unexpected address: %q", p.Location.Formatted)
+		}
+	}
+	if fil.Info.Lang != LangFilipino || en.Info.Lang != LangEnglish {
+		t.Errorf("expected info.lang to report the language, got: %q and %q", fil.Info.Lang, en.Info.Lang)
+	}
+
+	date := time.Date(1994, time.March, 5, 0, 0, 0, 0, time.UTC)
+	if got := LangFilipino.formatDate(date); got != "Marso 5, 1994" {
+		t.Errorf("expected %q, got: %q", "Marso 5, 1994", got)
+	}
+}
+
+// TestDateFormats checks each format's JSON, including the ISO week year
+// differing from the calendar year.
+func TestDateFormats(t *testing.T) {
+	march := time.Date(1994, time.March, 5, 0, 0, 0, 0, time.UTC)
+	sunday := time.Date(2021, time.January, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		date Date
+		want string
+	}{
+		{Date{march, DateRFC3339}, `"1994-03-05T00:00:00Z"`},
+		{Date{march, DateUnix}, `762825600`},
+		{Date{march, DateYMD}, `"1994-03-05"`},
+		{Date{march, DateMDY}, `"03/05/1994"`},
+		{Date{march, DateISOWeek}, `"1994-W09-6"`},
+		{Date{sunday, DateISOWeek}, `"2020-W53-7"`},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.date)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s: expected %s, got: %s", tt.date.Format, tt.want, got)
+		}
+	}
+}
+
+// TestBirthWindow checks every age's window is exactly the days giving that
+// age, including around leap days.
+func TestBirthWindow(t *testing.T) {
+	refs := []time.Time{
+		time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	for _, ref := range refs {
+		for age := minAge; age < maxAge; age++ {
+			first, last := birthWindow(age, ref)
+			if ageAt(first, ref) != age || ageAt(last, ref) != age {
+				t.Errorf("%s, age %d: window %s to %s has the wrong age", ref, age, first, last)
+			}
+			if ageAt(first.AddDate(0, 0, -1), ref) == age || ageAt(last.AddDate(0, 0, 1), ref) == age {
+				t.Errorf("%s, age %d: window %s to %s is too narrow", ref, age, first, last)
+			}
+		}
+	}
+}
+
+// TestDOBMatchesAge checks, over many people, that Age always agrees with
+// the DOB and that birthdays cover every day of the month.
+func TestDOBMatchesAge(t *testing.T) {
+	d := testData()
+	ref := fixRef(d)
+	gen := NewPinoyGenerator(&config.Config{}, d)
+
+	days := make(map[int]bool)
+	for _, seed := range []string{"a", "b", "c", "d"} {
+		resp, err := gen.Generate(1000, seed, Options{})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		for _, p := range *resp.Results {
+			dob := p.DOB.Date.Time
+			if p.DOB.Age != ageAt(dob, ref) {
+				t.Errorf("born %s but age %d", dob, p.DOB.Age)
+			}
+			if p.DOB.Age < minAge || p.DOB.Age >= maxAge {
+				t.Errorf("age %d out of range", p.DOB.Age)
+			}
+			days[dob.Day()] = true
+		}
+	}
+
+	for day := 1; day <= 31; day++ {
+		if !days[day] {
+			t.Errorf("no one was born on day %d of any month", day)
+		}
+	}
+}
+
+// TestCivilStatus checks statuses follow age and that only women using a
+// husband's surname are titled "Mrs".
+func TestCivilStatus(t *testing.T) {
+	gen := NewPinoyGenerator(&config.Config{}, testData())
+
+	resp, err := gen.Generate(1000, "8959bcbac47d82c434fd8f154dab3e04", Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	counts := make(map[CivilStatus]int)
+	for _, p := range *resp.Results {
+		counts[p.CivilStatus]++
+
+		if p.DOB.Age <= 24 && (p.CivilStatus == CivilWidowed || p.CivilStatus == CivilAnnulled) {
+			t.Errorf("%d-year-old is %s", p.DOB.Age, p.CivilStatus)
+		}
+		if p.Name.Title == "Mrs" && (p.Gender != "female" || !p.CivilStatus.wasMarried()) {
+			t.Errorf("%s %s is titled Mrs", p.Gender, p.CivilStatus)
+		}
+		if p.Name.Middle == "" || p.Name.Middle == p.Name.Last {
+			t.Errorf("expected a middle name other than %q, got: %q", p.Name.Last, p.Name.Middle)
+		}
+	}
+
+	if counts[CivilSingle] == 0 || counts[CivilMarried] == 0 || counts[CivilWidowed] == 0 {
+		t.Errorf("expected a mix of civil statuses, got: %v", counts)
+	}
+}
+
+// TestRegistrationDates checks sign-ups are in the past, after the 18th
+// birthday, within the window, and that Age matches the date.
+func TestRegistrationDates(t *testing.T) {
+	d := testData()
+	ref := fixRef(d)
+	gen := NewPinoyGenerator(&config.Config{}, d)
+
+	for _, years := range []int{1, 5, 50} {
+		resp, err := gen.Generate(500, "8959bcbac47d82c434fd8f154dab3e04", Options{MaxRegistrationYears: years})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		for _, p := range *resp.Results {
+			reg, dob := p.Registered.Date.Time, p.DOB.Date.Time
+			if reg.After(ref) {
+				t.Errorf("registered in the future: %s", reg)
+			}
+			if reg.Before(dob.AddDate(minAge, 0, 0)) {
+				t.Errorf("registered %s before turning 18 (born %s)", reg, dob)
+			}
+			if reg.Before(ref.AddDate(-years, 0, 0)) {
+				t.Errorf("registered %s, more than %d years ago", reg, years)
+			}
+			if p.Registered.Age != ageAt(reg, ref) || p.Registered.Age >= years {
+				t.Errorf("registered %s with age %d", reg, p.Registered.Age)
+			}
+		}
+	}
+}
+
+// TestEmployment keeps start dates in adulthood and salaries in their band,
+// and leaves the block out for datasets without industries.
+func TestEmployment(t *testing.T) {
+	d := checkedInData(t)
+	ref := fixRef(d)
+	gen := NewPinoyGenerator(&config.Config{}, d)
+
+	resp, err := gen.Generate(500, "8959bcbac47d82c434fd8f154dab3e04", Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	industries := make(map[string]data.Industry)
+	for _, ind := range d.Employment.Industries {
+		industries[ind.Name] = ind
+	}
+	for _, p := range *resp.Results {
+		e := p.Employment
+		start, dob := e.StartDate.Time, p.DOB.Date.Time
+		if start.After(ref) || start.Before(dob.AddDate(minAge, 0, 0)) {
+			t.Errorf("started %s, born %s", start, dob)
+		}
+		if strings.Contains(e.Employer, "{") {
+			t.Errorf("unfilled employer template: %s", e.Employer)
+		}
+
+		ind, ok := industries[e.Industry]
+		if !ok {
+			t.Fatalf("unknown industry %q", e.Industry)
+		}
+		i := slices.IndexFunc(ind.Jobs, func(j data.Job) bool { return j.Title == e.JobTitle })
+		if i < 0 {
+			t.Fatalf("job %q not in %s", e.JobTitle, e.Industry)
+		}
+		if job := ind.Jobs[i]; e.MonthlySalary < job.SalaryMin || e.MonthlySalary > job.SalaryMax {
+			t.Errorf("%s paid %d, band is %d-%d", job.Title, e.MonthlySalary, job.SalaryMin, job.SalaryMax)
+		}
+	}
+
+	resp, err = NewPinoyGenerator(&config.Config{}, testData()).Generate(1, "", Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if e := (*resp.Results)[0].Employment; e != (Employment{}) {
+		t.Errorf("expected no employment without industries, got: %+v", e)
+	}
+}
+
+// TestOverseas sends everyone or no one abroad with ofw_ratio, keeping their
+// PH address, and keeps each contract running at the reference date.
+func TestOverseas(t *testing.T) {
+	d := checkedInData(t)
+	ref := fixRef(d)
+	gen := NewPinoyGenerator(&config.Config{}, d)
+
+	for _, ratio := range []float64{0, 1} {
+		resp, err := gen.Generate(300, "8959bcbac47d82c434fd8f154dab3e04", Options{OFWRatio: &ratio})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		for _, p := range *resp.Results {
+			abroad := p.Overseas != (Overseas{})
+			if abroad != (ratio == 1) {
+				t.Fatalf("ofw_ratio=%v: %s works in %q abroad=%v", ratio, p.Employment.JobTitle, p.Employment.Industry, abroad)
+			}
+			if p.Location.Country != "Philippines" || p.Location.Region == "" {
+				t.Errorf("expected a PH home address, got: %+v", p.Location)
+			}
+			if !abroad {
+				continue
+			}
+
+			c := p.Overseas.Contract
+			deployed, end := c.DeploymentDate.Time, c.EndDate.Time
+			if deployed.Before(p.Employment.StartDate.Time) || deployed.After(ref) {
+				t.Errorf("deployed %s, employed since %s", deployed, p.Employment.StartDate.Time)
+			}
+			if !end.Equal(deployed.AddDate(0, c.DurationMonths, 0)) || !end.After(ref) {
+				t.Errorf("%d-month contract from %s ends %s", c.DurationMonths, deployed, end)
+			}
+			if !strings.HasPrefix(c.OECNumber, strconv.Itoa(deployed.Year())+"-") {
+				t.Errorf("OEC %s for a %d deployment", c.OECNumber, deployed.Year())
+			}
+
+			i := slices.IndexFunc(d.Overseas.Countries, func(x data.Country) bool { return x.Name == p.Overseas.Country })
+			if i < 0 {
+				t.Fatalf("unknown country %q", p.Overseas.Country)
+			}
+			country := d.Overseas.Countries[i]
+			if !slices.Contains(country.Cities, p.Overseas.City) || c.Currency != country.Currency {
+				t.Errorf("%s, %s paid in %s", p.Overseas.City, country.Name, c.Currency)
+			}
+			if !strings.HasPrefix(p.Overseas.Phone, "+"+country.CallingCode) {
+				t.Errorf("phone %s outside %s", p.Overseas.Phone, country.Name)
+			}
+		}
+	}
+}
+
+// TestEducation lines graduation years up with the DOB and the K-12
+// switch, keeps colleges near home, and degree jobs for graduates.
+func TestEducation(t *testing.T) {
+	d := checkedInData(t)
+	ref := fixRef(d)
+	gen := NewPinoyGenerator(&config.Config{}, d)
+
+	resp, err := gen.Generate(1000, "8959bcbac47d82c434fd8f154dab3e04", Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	degreeJobs := make(map[string]bool)
+	for _, ind := range d.Employment.Industries {
+		for _, j := range ind.Jobs {
+			degreeJobs[j.Title] = j.RequiresDegree
+		}
+	}
+	seen := make(map[Attainment]bool)
+	for _, p := range *resp.Results {
+		e := p.Education
+		seen[e.Attainment] = true
+		if e.GraduationYear >= ref.Year() {
+			t.Errorf("graduated in %d, after %s", e.GraduationYear, ref)
+		}
+
+		// ? NOTE: Age at the March graduation, give or take the August 31 cutoff
+		age := e.GraduationYear - p.DOB.Date.Time.Year()
+		switch e.Attainment {
+		case AttainmentElementary:
+			if age < 12 || age > 13 {
+				t.Errorf("finished elementary at %d", age)
+			}
+		case AttainmentJuniorHigh:
+			if age < 16 || age > 17 || e.GraduationYear <= lastFourYearHighSchoolClass {
+				t.Errorf("finished junior high in %d at %d", e.GraduationYear, age)
+			}
+		case AttainmentHighSchool:
+			k12 := e.GraduationYear > lastFourYearHighSchoolClass
+			if k12 != (age >= 18) || age < 16 || age > 19 || k12 != (e.Course != "") {
+				t.Errorf("finished high school in %d at %d, strand %q", e.GraduationYear, age, e.Course)
+			}
+		case AttainmentCollege:
+			i := slices.IndexFunc(d.Education.Colleges, func(c data.College) bool { return c.Name == e.School })
+			region := p.Location.Region
+			if i < 0 || (d.Education.Colleges[i].Region != region && d.Education.Colleges[i].Region != ncrRegion) {
+				t.Errorf("%s lives in region %s, went to %q", p.Name.First, region, e.School)
+			}
+			if age < 20 || e.Course == "" {
+				t.Errorf("graduated %q at %d", e.Course, age)
+			}
+		default:
+			t.Fatalf("unknown attainment %q", e.Attainment)
+		}
+
+		if start := p.Employment.StartDate.Time; start.Year() < e.GraduationYear {
+			t.Errorf("started work %s, graduated %d", start, e.GraduationYear)
+		}
+		if degreeJobs[p.Employment.JobTitle] && e.Attainment != AttainmentCollege {
+			t.Errorf("%s with %s attainment", p.Employment.JobTitle, e.Attainment)
+		}
+	}
+	for _, a := range []Attainment{AttainmentElementary, AttainmentHighSchool, AttainmentCollege} {
+		if !seen[a] {
+			t.Errorf("expected some %s graduates in 1000", a)
+		}
+	}
+}
+
+// TestFinance checks cards are Luhn-valid published test cards, and
+// wallets sit on the person's own mobile number.
+func TestFinance(t *testing.T) {
+	d := checkedInData(t)
+	gen := NewPinoyGenerator(&config.Config{}, d)
+
+	resp, err := gen.Generate(500, "8959bcbac47d82c434fd8f154dab3e04", Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	for _, p := range *resp.Results {
+		f := p.Finance
+		card := f.Card
+		if !slices.Contains(testCards, testCard{card.Brand, card.Number}) {
+			t.Errorf("card %s %s is not a published test card", card.Brand, card.Number)
+		}
+		sum := 0
+		for i := range card.Number {
+			d := int(card.Number[len(card.Number)-1-i] - '0')
+			if i%2 == 1 {
+				d *= 2
+				if d > 9 {
+					d -= 9
+				}
+			}
+			sum += d
+		}
+		if sum%10 != 0 {
+			t.Errorf("card %s fails the Luhn check", card.Number)
+		}
+
+		if f.Wallet.MobileNumber == "" || f.Wallet.MobileNumber != p.Phone {
+			t.Errorf("wallet on %q, phone is %q", f.Wallet.MobileNumber, p.Phone)
+		}
+
+		i := slices.IndexFunc(d.Finance.Banks, func(b data.Bank) bool { return b.Name == f.Bank.Name })
+		if i < 0 {
+			t.Fatalf("unknown bank %q", f.Bank.Name)
+		}
+		format := d.Finance.Banks[i].AccountFormat
+		if len(f.Bank.AccountNumber) != len(format) || strings.ContainsAny(f.Bank.AccountNumber, "#") {
+			t.Errorf("account %s does not follow %s", f.Bank.AccountNumber, format)
+		}
+	}
+}
+
+// TestHealth checks BMI agrees with height and weight, O+ is the most
+// common blood type and PhilHealth files people by their work.
+func TestHealth(t *testing.T) {
+	gen := NewPinoyGenerator(&config.Config{}, checkedInData(t))
+
+	resp, err := gen.Generate(1000, "8959bcbac47d82c434fd8f154dab3e04", Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	bloodTypes := make(map[string]int)
+	for _, p := range *resp.Results {
+		h := p.Health
+		bloodTypes[h.BloodType]++
+
+		m := h.HeightCm / 100
+		if bmi := h.WeightKg / (m * m); math.Abs(bmi-h.BMI) > 0.05 || bmi < bmiMin-0.5 || bmi > bmiMax+0.5 {
+			t.Errorf("%.1fcm %.1fkg with BMI %.1f", h.HeightCm, h.WeightKg, h.BMI)
+		}
+		if s := bodyStats[p.Gender]; h.HeightCm < s.heightMin || h.HeightCm > s.heightMax {
+			t.Errorf("%s %.1fcm tall", p.Gender, h.HeightCm)
+		}
+
+		want := PhilHealthEmployed
+		switch {
+		case p.Overseas != (Overseas{}):
+			want = PhilHealthMigrantWorker
+		case p.Employment == (Employment{}):
+			want = PhilHealthSelfEarning
+		}
+		if h.PhilHealth != want {
+			t.Errorf("%s earning %d filed as %s", p.Employment.Industry, p.Employment.MonthlySalary, h.PhilHealth)
+		}
+		for _, c := range h.Conditions {
+			if c.Code == "I10" && p.DOB.Age < 30 {
+				t.Errorf("hypertension at %d", p.DOB.Age)
+			}
+		}
+	}
+	for bt, n := range bloodTypes {
+		if bt != "O+" && n >= bloodTypes["O+"] {
+			t.Errorf("expected O+ to be the most common, got %v", bloodTypes)
+		}
+	}
+}
+
+// TestSocial checks handles are unique per platform, fit the platform's
+// rules, and every URL is on a reserved .example domain.
+func TestSocial(t *testing.T) {
+	d := checkedInData(t)
+	gen := NewPinoyGenerator(&config.Config{}, d)
+
+	resp, err := gen.Generate(1000, "8959bcbac47d82c434fd8f154dab3e04", Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	websites := 0
+	for _, p := range *resp.Results {
+		for _, a := range p.Social.Accounts {
+			i := slices.IndexFunc(d.Social.Platforms, func(pl data.Platform) bool { return pl.Name == a.Platform })
+			if i < 0 {
+				t.Fatalf("unknown platform %q", a.Platform)
+			}
+			pl := d.Social.Platforms[i]
+			if a.Handle == "" || len(a.Handle) > pl.MaxLength {
+				t.Errorf("%s handle %q is not 1-%d characters", a.Platform, a.Handle, pl.MaxLength)
+			}
+			if strings.Trim(a.Handle, "abcdefghijklmnopqrstuvwxyz0123456789"+pl.Separators) != "" {
+				t.Errorf("%s handle %q has characters outside [a-z0-9%s]", a.Platform, a.Handle, pl.Separators)
+			}
+			key := a.Platform + "/" + a.Handle
+			if seen[key] {
+				t.Errorf("%s handle %q is used twice", a.Platform, a.Handle)
+			}
+			seen[key] = true
+			if want := "https://" + strings.ToLower(a.Platform) + ".example/" + a.Handle; a.URL != want {
+				t.Errorf("expected URL %s, got: %s", want, a.URL)
+			}
+		}
+		if w := p.Social.Website; w != "" {
+			websites++
+			if !strings.HasPrefix(w, "https://") || !strings.HasSuffix(w, ".example") || seen[w] {
+				t.Errorf("website %q is not a unique .example site", w)
+			}
+			seen[w] = true
+		}
+	}
+	if websites == 0 {
+		t.Errorf("expected some websites in 1000")
+	}
+}
+
+// TestDocuments checks licenses, passports and plates follow their LTO and
+// DFA formats, are valid at ref, and OFWs got their passport before leaving.
+func TestDocuments(t *testing.T) {
+	d := checkedInData(t)
+	ref := fixRef(d)
+	gen := NewPinoyGenerator(&config.Config{}, d)
+
+	licensePattern := regexp.MustCompile(`^[A-Z]\d{2}-\d{2}-\d{6}$`)
+	passportPattern := regexp.MustCompile(`^P\d{7}[A-Z]$`)
+	platePattern := regexp.MustCompile(`^[A-Z]{3} (\d{3}|\d{4})$`)
+
+	ratio := 0.2
+	resp, err := gen.Generate(1000, "8959bcbac47d82c434fd8f154dab3e04", Options{OFWRatio: &ratio})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	claim := func(id string) {
+		if seen[id] {
+			t.Errorf("%s is issued twice", id)
+		}
+		seen[id] = true
+	}
+	var licenses, vehicles int
+	for _, p := range *resp.Results {
+		adult := p.DOB.Date.Time.AddDate(minAge, 0, 0)
+
+		if l := p.DriversLicense; l.Number != "" {
+			licenses++
+			claim(l.Number)
+			issued, expires := l.IssueDate.Time, l.ExpiryDate.Time
+			if !licensePattern.MatchString(l.Number) {
+				t.Errorf("license %s is not Lxx-xx-xxxxxx", l.Number)
+			}
+			if issued.Before(adult) || issued.After(ref) || expires.Before(ref) {
+				t.Errorf("license issued %s expiring %s for someone 18 on %s", issued, expires, adult)
+			}
+			if dob := p.DOB.Date.Time; expires.Format("01-02") != dob.Format("01-02") && dob.Format("01-02") != "02-29" {
+				t.Errorf("license expires %s, birthday is %s", expires, dob)
+			}
+			years := licenseValidYears
+			if !issued.Before(tenYearLicenseStart) {
+				years = cleanLicenseYears
+			}
+			if expires.After(issued.AddDate(years, 0, 0)) {
+				t.Errorf("license issued %s expires %s, more than %d years on", issued, expires, years)
+			}
+			if len(l.DLCodes) == 0 {
+				t.Errorf("license %s has no DL codes", l.Number)
+			}
+		}
+
+		if v := p.Vehicle; v.Plate != "" {
+			vehicles++
+			claim(v.Plate)
+			if !slices.Contains(p.DriversLicense.DLCodes, "B") {
+				t.Errorf("%s %s owned without a license for cars: %v", v.Make, v.Model, p.DriversLicense.DLCodes)
+			}
+			if !platePattern.MatchString(v.Plate) || (len(v.Plate) == 8) != (v.Year >= currentPlateYear) {
+				t.Errorf("plate %s on a %d %s %s", v.Plate, v.Year, v.Make, v.Model)
+			}
+		}
+
+		ps := p.Passport
+		if ps.Number == "" {
+			if p.Overseas.Country != "" {
+				t.Errorf("OFW in %s without a passport", p.Overseas.Country)
+			}
+			continue
+		}
+		claim(ps.Number)
+		issued := ps.IssueDate.Time
+		if !passportPattern.MatchString(ps.Number) {
+			t.Errorf("passport %s is not P#######A", ps.Number)
+		}
+		if issued.Before(adult) || issued.After(ref) || !ps.ExpiryDate.Time.After(ref) {
+			t.Errorf("passport issued %s expiring %s", issued, ps.ExpiryDate.Time)
+		}
+		if deployed := p.Overseas.Contract.DeploymentDate.Time; p.Overseas.Country != "" && issued.After(deployed) {
+			t.Errorf("passport issued %s, deployed %s", issued, deployed)
+		}
+	}
+	if licenses == 0 || vehicles == 0 {
+		t.Errorf("expected some licenses and vehicles in 1000, got %d and %d", licenses, vehicles)
+	}
+}
+
+// TestFieldSelection leaves out unselected fields without changing the rest.
+func TestFieldSelection(t *testing.T) {
+	gen := NewPinoyGenerator(&config.Config{}, checkedInData(t))
+	seed := "8959bcbac47d82c434fd8f154dab3e04"
+
+	all, err := gen.Generate(5, seed, Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	inc, err := gen.Generate(5, seed, Options{Include: []Field{FieldName, FieldHealth}, Exclude: []Field{FieldHealth}})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	exc, err := gen.Generate(5, seed, Options{Exclude: []Field{FieldHealth, FieldFinance}})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	for i, p := range *all.Results {
+		want := Pinoy{Name: p.Name}
+		if got := (*inc.Results)[i]; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected only the name, got: %+v", got)
+		}
+
+		p.Health, p.Finance = Health{}, Finance{}
+		if got := (*exc.Results)[i]; !reflect.DeepEqual(got, p) {
+			t.Errorf("expected everything but health and finance, got: %+v", got)
+		}
+	}
+
+	raw, err := json.Marshal((*inc.Results)[0])
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &keys); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(keys) != 1 || keys["name"] == nil {
+		t.Errorf("expected only name in the JSON, got: %s", raw)
+	}
+}
+
+// TestRegionalIdentity draws ethnicity, religion and languages from the
+// person's own region, so BARMM is mostly Muslim and NCR mostly Tagalog.
+func TestRegionalIdentity(t *testing.T) {
+	d := checkedInData(t)
+	gen := NewPinoyGenerator(&config.Config{}, d)
+
+	resp, err := gen.Generate(1000, "8959bcbac47d82c434fd8f154dab3e04", Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	regions := make(map[string]data.Location)
+	for _, loc := range d.Locations {
+		regions[loc.Region] = loc
+	}
+	muslim, barmm, tagalog, ncr := 0, 0, 0, 0
+	for _, p := range *resp.Results {
+		region := regions[p.Location.Region]
+		i := slices.IndexFunc(region.Ethnicities, func(e data.Ethnicity) bool { return e.Name == p.Ethnicity })
+		if i < 0 {
+			t.Fatalf("%s is not an ethnicity of %s", p.Ethnicity, region.Region)
+		}
+		e := region.Ethnicities[i]
+		if p.Languages[0] != e.Language || (e.Religion != "" && p.Religion != e.Religion) {
+			t.Errorf("%s speaking %v, %s", p.Ethnicity, p.Languages, p.Religion)
+		}
+		if len(slices.Compact(slices.Sorted(slices.Values(p.Languages)))) != len(p.Languages) {
+			t.Errorf("languages repeat: %v", p.Languages)
+		}
+
+		switch region.Region {
+		case "BARMM":
+			barmm++
+			if p.Religion == "Islam" {
+				muslim++
+			}
+		case ncrRegion:
+			ncr++
+			if p.Ethnicity == "Tagalog" {
+				tagalog++
+			}
+		}
+	}
+	if muslim*10 < barmm*7 || tagalog*10 < ncr*6 {
+		t.Errorf("expected mostly Muslims in BARMM and Tagalogs in NCR, got %d/%d and %d/%d", muslim, barmm, tagalog, ncr)
+	}
+
+	households, err := gen.GenerateHouseholds(50, "8959bcbac47d82c434fd8f154dab3e04", Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	for _, h := range *households.Results {
+		head := h.Members[0]
+		for _, m := range h.Members[1:] {
+			if m.Ethnicity != head.Ethnicity || m.Religion != head.Religion || m.Languages[0] != head.Languages[0] {
+				t.Errorf("%s %s in a %s %s household", m.Ethnicity, m.Religion, head.Ethnicity, head.Religion)
+			}
+		}
+	}
+}
+
+// TestJitterCoordinatesWithinRadius checks points never leave the city's radius.
+func TestJitterCoordinatesWithinRadius(t *testing.T) {
+	rng := newRNGfromSeed("8959bcbac47d82c434fd8f154dab3e04")
+	city := data.City{Name: "Cebu City", Latitude: 10.3157, Longitude: 123.8854, RadiusKm: 8}
+
+	for range 1000 {
+		lat, lon := jitterCoordinates(city, rng)
+		dy := (lat - city.Latitude) * kmPerDegreeLat
+		dx := (lon - city.Longitude) * kmPerDegreeLat * math.Cos(city.Latitude*math.Pi/180)
+		if d := math.Hypot(dx, dy); d > city.RadiusKm+1e-9 {
+			t.Fatalf("point %.4f,%.4f is %.2fkm from centroid, radius %.0fkm", lat, lon, d, city.RadiusKm)
+		}
+	}
+}
+
+// checkedInData loads data/data.json, big enough for whole families.
+func checkedInData(t *testing.T) *data.Data {
+	t.Helper()
+	raw, err := os.ReadFile("../../data/data.json")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	d, err := data.Load(raw, nil, false)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	return d
+}
+
+// TestHouseholds checks family structure, naming and shared contact details
+// over many households, and that a seed reproduces them.
+func TestHouseholds(t *testing.T) {
+	gen := NewPinoyGenerator(&config.Config{}, checkedInData(t))
+
+	resp, err := gen.GenerateHouseholds(300, "8959bcbac47d82c434fd8f154dab3e04", Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	again, err := gen.GenerateHouseholds(300, "8959bcbac47d82c434fd8f154dab3e04", Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !reflect.DeepEqual(resp, again) {
+		t.Errorf("expected the same households for the same seed")
+	}
+
+	for _, h := range *resp.Results {
+		head := h.Members[0]
+		if head.Relationship != RelationshipHead {
+			t.Fatalf("expected the head first, got: %s", head.Relationship)
+		}
+
+		var husband, wife *HouseholdMember
+		parentAge := head.DOB.Age
+		if len(h.Members) > 1 && h.Members[1].Relationship == RelationshipSpouse {
+			spouse := &h.Members[1]
+			if head.CivilStatus != CivilMarried || spouse.CivilStatus != CivilMarried || spouse.Gender == head.Gender {
+				t.Errorf("unexpected couple: %s %s and %s %s", head.Gender, head.CivilStatus, spouse.Gender, spouse.CivilStatus)
+			}
+			husband, wife = &h.Members[0], spouse
+			if head.Gender == "female" {
+				husband, wife = spouse, &h.Members[0]
+			}
+			if wife.Name.Title == "Mrs" && wife.Name.Last != husband.Name.Last {
+				t.Errorf("Mrs %s should carry her husband's surname %s", wife.Name.Last, husband.Name.Last)
+			}
+			parentAge = min(head.DOB.Age, spouse.DOB.Age)
+		} else if head.CivilStatus == CivilMarried {
+			t.Errorf("married head without a spouse")
+		}
+
+		firsts := make(map[string]bool)
+		for _, m := range h.Members {
+			if firsts[m.Name.First] {
+				t.Errorf("two members named %s", m.Name.First)
+			}
+			firsts[m.Name.First] = true
+
+			if m.Location != head.Location || m.Landline != head.Landline {
+				t.Errorf("%s doesn't share the head's address and landline", m.Name.First)
+			}
+			if (m.DOB.Age >= minAge) != (m.Email != "") {
+				t.Errorf("%d-year-old with email %q", m.DOB.Age, m.Email)
+			}
+
+			if m.Relationship != RelationshipSon && m.Relationship != RelationshipDaughter {
+				continue
+			}
+			if m.DOB.Age > parentAge-minAge {
+				t.Errorf("child aged %d with a %d-year-old parent", m.DOB.Age, parentAge)
+			}
+			if husband != nil && m.Name.Last != husband.Name.Last {
+				t.Errorf("child %s should carry the father's surname %s", m.Name.Last, husband.Name.Last)
+			}
+			if wife != nil {
+				maiden := wife.Name.Last
+				if wife.Name.Title == "Mrs" {
+					maiden = wife.Name.Middle
+				}
+				if m.Name.Middle != maiden {
+					t.Errorf("child's middle name %s should be the mother's maiden name %s", m.Name.Middle, maiden)
+				}
+			}
+		}
+	}
+
+	v1 := testData()
+	v1.Version = 1
+	if _, err := NewPinoyGenerator(&config.Config{}, v1).GenerateHouseholds(1, "a", Options{}); !errors.Is(err, ErrNoHouseholds) {
+		t.Errorf("expected ErrNoHouseholds, got: %v", err)
+	}
+}
+
+// TestBusinesses checks registrations and TINs follow the DTI, SEC and BIR
+// formats, owners live in the business's region and had turned 18 by
+// registration, and a seed reproduces the businesses.
+func TestBusinesses(t *testing.T) {
+	gen := NewPinoyGenerator(&config.Config{}, checkedInData(t))
+
+	resp, err := gen.GenerateBusinesses(300, "8959bcbac47d82c434fd8f154dab3e04", Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	again, err := gen.GenerateBusinesses(300, "8959bcbac47d82c434fd8f154dab3e04", Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !reflect.DeepEqual(resp, again) {
+		t.Errorf("expected the same businesses for the same seed")
+	}
+
+	dtiPattern := regexp.MustCompile(`^\d{7}$`)
+	secPattern := regexp.MustCompile(`^(CS|PG)(\d{4})\d{5}$`)
+	tinPattern := regexp.MustCompile(`^\d{3}-\d{3}-\d{3}-\d{5}$`)
+
+	names := make(map[string]bool)
+	for _, biz := range *resp.Results {
+		if names[biz.Name] {
+			t.Errorf("business name %q is registered twice", biz.Name)
+		}
+		names[biz.Name] = true
+
+		reg := biz.Registration
+		switch biz.Type {
+		case BusinessSoleProprietorship:
+			if reg.Agency != "DTI" || !dtiPattern.MatchString(reg.Number) {
+				t.Errorf("sole proprietorship registered with %s as %s", reg.Agency, reg.Number)
+			}
+		case BusinessPartnership, BusinessCorporation:
+			m := secPattern.FindStringSubmatch(reg.Number)
+			if reg.Agency != "SEC" || m == nil || m[2] != strconv.Itoa(reg.Date.Time.Year()) {
+				t.Errorf("%s registered with %s as %s on %s", biz.Type, reg.Agency, reg.Number, reg.Date.Time)
+			}
+		default:
+			t.Errorf("unknown business type %q", biz.Type)
+		}
+		if !tinPattern.MatchString(biz.TIN) {
+			t.Errorf("TIN %s is not ###-###-###-#####", biz.TIN)
+		}
+
+		owner := biz.Owner
+		if owner.Location.Region != biz.Location.Region {
+			t.Errorf("owner lives in %s, business is in %s", owner.Location.Region, biz.Location.Region)
+		}
+		if reg.Date.Time.Before(owner.DOB.Date.Time.AddDate(minAge, 0, 0)) {
+			t.Errorf("registered %s by an owner born %s", reg.Date.Time, owner.DOB.Date.Time)
+		}
+		if owner.Employment != (Employment{}) || owner.Email == "" {
+			t.Errorf("expected an adult owner without an employer, got: %+v", owner.Employment)
+		}
+	}
+
+	v1 := testData()
+	v1.Version = 1
+	if _, err := NewPinoyGenerator(&config.Config{}, v1).GenerateBusinesses(1, "a", Options{}); !errors.Is(err, ErrNoBusinesses) {
+		t.Errorf("expected ErrNoBusinesses, got: %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package generator
+
+import (
+	"math"
+	mathrand "math/rand/v2"
+
+	"github.com/mrjxtr/rpug/internal/data"
+)
+
+const (
+	kmPerDegreeLat = 111.32
+	timezoneOffset = "+08:00"
+	timezoneName   = "Asia/Manila"
+)
+
+// jitterCoordinates returns a point drawn uniformly from the disk of
+// city.RadiusKm around the city centroid.
+func jitterCoordinates(city data.City, rng *mathrand.Rand) (lat, lon float64) {
+	// sqrt keeps the density even; without it points bunch up at the centre
+	dist := city.RadiusKm * math.Sqrt(rng.Float64())
+	angle := 2 * math.Pi * rng.Float64()
+
+	dLat := dist * math.Cos(angle) / kmPerDegreeLat
+	dLon := dist * math.Sin(angle) / (kmPerDegreeLat * math.Cos(city.Latitude*math.Pi/180))
+
+	return city.Latitude + dLat, city.Longitude + dLon
+}
@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"math"
+
+	"github.com/mrjxtr/rpug/internal/data"
+)
+
+// PhilHealthCategory is the PhilHealth membership category under the
+// Universal Health Care Act.
+type PhilHealthCategory string
+
+const (
+	PhilHealthEmployed      PhilHealthCategory = "employed"
+	PhilHealthSelfEarning   PhilHealthCategory = "self-earning"
+	PhilHealthMigrantWorker PhilHealthCategory = "migrant worker"
+	PhilHealthIndigent      PhilHealthCategory = "indigent"
+)
+
+// indigentVersion is the last dataset version that files employees earning
+// under indigentSalary a month as indigent. PhilHealth only counts as
+// indigent the poor the DSWD has identified, which nothing here models, so
+// later versions file them as employed.
+const (
+	indigentVersion = 2
+	indigentSalary  = 10_000
+)
+
+// bodyStats is the height and BMI of adults of one gender, loosely after
+// NCD-RisC and FNRI surveys. BMI rises by bmiPerYear from 20 to 50.
+var bodyStats = map[string]struct {
+	heightMean, heightSD, heightMin, heightMax float64
+	bmiMean, bmiSD                             float64
+}{
+	"male":   {163.5, 6.5, 145, 190, 23.0, 3.5},
+	"female": {151.8, 6.0, 135, 180, 23.5, 3.8},
+}
+
+const (
+	bmiPerYear = 0.06
+	bmiMin     = 16.0
+	bmiMax     = 40.0
+)
+
+// Health is what a clinic would have on file. Height is in cm and weight in kg.
+type Health struct {
+	BloodType  string             `json:"blood_type"`
+	HeightCm   float64            `json:"height_cm"`
+	WeightKg   float64            `json:"weight_kg"`
+	BMI        float64            `json:"bmi"`
+	PhilHealth PhilHealthCategory `json:"philhealth_category"`
+	Allergies  []MedicalCode      `json:"allergies,omitempty"`
+	Conditions []MedicalCode      `json:"conditions,omitempty"`
+}
+
+// MedicalCode is an ICD-10-CM code and its description.
+type MedicalCode struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// fillHealth gives an adult a blood type, body measurements for their gender
+// and age, a PhilHealth category and, now and then, allergies and conditions.
+// It runs after fillEmployment.
+func (b *batch) fillHealth(p *Pinoy) {
+	h := b.d.Health
+	if len(h.BloodTypes) == 0 {
+		return
+	}
+	rng := b.rng
+	age := p.DOB.Age
+
+	p.Health.BloodType = pickWeighted(h.BloodTypes, func(bt data.BloodType) int { return bt.Weight }, rng).Type
+
+	// ? NOTE: Older adults are a little shorter and heavier; BMI is worked out
+	// ? from the rounded height and weight so the three always agree
+	s := bodyStats[p.Gender]
+	height := s.heightMean + rng.NormFloat64()*s.heightSD - max(float64(age-50), 0)*0.1
+	height = math.Round(min(max(height, s.heightMin), s.heightMax)*10) / 10
+	bmi := s.bmiMean + float64(min(max(age-20, 0), 30))*bmiPerYear + rng.NormFloat64()*s.bmiSD
+	bmi = min(max(bmi, bmiMin), bmiMax)
+	m := height / 100
+	p.Health.HeightCm = height
+	p.Health.WeightKg = math.Round(bmi*m*m*10) / 10
+	p.Health.BMI = math.Round(p.Health.WeightKg/(m*m)*10) / 10
+
+	p.Health.PhilHealth = philHealthCategory(p, b.d.Version)
+	p.Health.Allergies = b.drawCodes(h.Allergies, age)
+	p.Health.Conditions = b.drawCodes(h.Conditions, age)
+}
+
+// philHealthCategory files p by work: OFWs first, then employees. Without
+// an Employment block they are self-earning.
+func philHealthCategory(p *Pinoy, version int) PhilHealthCategory {
+	switch {
+	case p.Overseas != (Overseas{}):
+		return PhilHealthMigrantWorker
+	case p.Employment == (Employment{}):
+		return PhilHealthSelfEarning
+	case version <= indigentVersion && p.Employment.MonthlySalary < indigentSalary:
+		return PhilHealthIndigent
+	default:
+		return PhilHealthEmployed
+	}
+}
+
+// drawCodes gives each code that applies at age its Percent chance.
+func (b *batch) drawCodes(codes []data.MedicalCode, age int) []MedicalCode {
+	var out []MedicalCode
+	for _, c := range codes {
+		if age >= c.MinAge && b.rng.IntN(100) < c.Percent {
+			out = append(out, MedicalCode{Code: c.Code, Name: c.Name})
+		}
+	}
+	return out
+}
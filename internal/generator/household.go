@@ -0,0 +1,265 @@
+package generator
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	householdHeadMinAge = 25
+	maleHeadPercent     = 70 // most PH household heads are men
+	spouseAgeGap        = 5  // spouses are within this many years of the head
+	singleParentPercent = 30 // share of never-married heads with children
+)
+
+// ErrNoHouseholds is returned for dataset versions from before civil status,
+// such as frozen version 1: households were added with version 2.
+var ErrNoHouseholds = errors.New("no households in data version")
+
+// Relationship is a household member's relation to the head.
+type Relationship string
+
+const (
+	RelationshipHead     Relationship = "head"
+	RelationshipSpouse   Relationship = "spouse"
+	RelationshipSon      Relationship = "son"
+	RelationshipDaughter Relationship = "daughter"
+)
+
+// HouseholdMember is a Pinoy plus their place in the household.
+// Members under 18 have no phone, email, login, registration, social or IDs.
+type HouseholdMember struct {
+	Relationship Relationship `json:"relationship"`
+	Pinoy
+}
+
+// Household is a family sharing one address and landline, head first.
+type Household struct {
+	Members []HouseholdMember `json:"members"`
+}
+
+type HouseholdResponse struct {
+	Results *[]Household `json:"results"`
+	Info    Info         `json:"info"`
+}
+
+// lineage is the names children inherit: the father's surname as Last and
+// the mother's maiden name as Middle. An empty field is drawn at random;
+// noMiddle is for a single mother's children, who carry her surname only.
+type lineage struct {
+	last, middle string
+	noMiddle     bool
+}
+
+type childWeight struct {
+	children int
+	weight   int
+}
+
+// childrenWeights is how many children live with a couple, or with a head
+// who was married.
+var childrenWeights = []childWeight{{0, 15}, {1, 20}, {2, 25}, {3, 20}, {4, 12}, {5, 8}}
+
+// GenerateHouseholds creates a HouseholdResponse with n households.
+// Seeds, options and errors work as in Generate.
+func (g *PinoyGenerator) GenerateHouseholds(
+	resParam int,
+	seedParam string,
+	opts Options,
+) (*HouseholdResponse, error) {
+	d, seed, rng, err := g.begin(seedParam, &opts)
+	if err != nil {
+		return nil, err
+	}
+	if d.Version < civilStatusVersion {
+		return nil, fmt.Errorf("%w: %d", ErrNoHouseholds, d.Version)
+	}
+
+	b := g.newBatch(d, resParam*3, rng, opts)
+
+	households := make([]Household, resParam)
+	for i := range households {
+		h, err := b.household()
+		if err != nil {
+			return nil, err
+		}
+		households[i] = h
+	}
+
+	info, err := g.generateInfo(d, len(households), seed, opts.Lang)
+	if err != nil {
+		return &HouseholdResponse{}, err
+	}
+
+	return &HouseholdResponse{
+		Results: &households,
+		Info:    info,
+	}, nil
+}
+
+// household draws a head, a spouse if the head is married, and children
+// young enough for the parents, all at one address.
+func (b *batch) household() (Household, error) {
+	rng := b.rng
+
+	pl := b.drawPlace(b.drawRegion())
+	landline := generateLandline(pl.city, rng)
+
+	headAge := rng.IntN(maxAge-householdHeadMinAge) + householdHeadMinAge
+	headMale := rng.IntN(100) < maleHeadPercent
+	headStatus := generateCivilStatus(headAge, rng)
+
+	h := Household{Members: []HouseholdMember{{Relationship: RelationshipHead}}}
+	head := &h.Members[0].Pinoy
+	youngestParent := headAge
+
+	// ? NOTE: Parents don't give two of their children the same first name
+	family := make(uniqueSet)
+	var kids lineage
+
+	if headStatus == CivilMarried {
+		spouseAge := headAge + rng.IntN(2*spouseAgeGap+1) - spouseAgeGap
+		spouseAge = min(max(spouseAge, minAge), maxAge-1)
+		youngestParent = min(headAge, spouseAge)
+
+		h.Members = append(h.Members, HouseholdMember{Relationship: RelationshipSpouse})
+		head = &h.Members[0].Pinoy
+		spouse := &h.Members[1].Pinoy
+
+		husband, wife, husbandAge, wifeAge := head, spouse, headAge, spouseAge
+		if !headMale {
+			husband, wife, husbandAge, wifeAge = spouse, head, spouseAge, headAge
+		}
+		wed := rng.IntN(100) < husbandSurnamePercent
+
+		// ? NOTE: The husband's name comes first so the wife can take his surname
+		if err := b.fillPerson(husband, generateDOB(husbandAge, b.ref, rng), CivilMarried, nameSpec{male: true, family: family}); err != nil {
+			return h, err
+		}
+		wifeName := nameSpec{wed: wed, family: family}
+		if wed {
+			wifeName.last = husband.Name.Last
+		}
+		if err := b.fillPerson(wife, generateDOB(wifeAge, b.ref, rng), CivilMarried, wifeName); err != nil {
+			return h, err
+		}
+
+		kids = lineage{last: husband.Name.Last, middle: wife.Name.Last}
+		if wed {
+			kids.middle = wife.Name.Middle
+		}
+	} else {
+		wed := !headMale && headStatus.wasMarried() && rng.IntN(100) < husbandSurnamePercent
+		if err := b.fillPerson(head, generateDOB(headAge, b.ref, rng), headStatus, nameSpec{male: headMale, wed: wed, family: family}); err != nil {
+			return h, err
+		}
+
+		// ? NOTE: A never-married mother's children take her surname, with no Middle
+		switch {
+		case headMale:
+			kids = lineage{last: head.Name.Last}
+		case wed:
+			kids = lineage{last: head.Name.Last, middle: head.Name.Middle}
+		case headStatus == CivilSingle:
+			kids = lineage{last: head.Name.Last, noMiddle: true}
+		default:
+			kids = lineage{middle: head.Name.Last}
+		}
+	}
+
+	if err := b.addChildren(&h, youngestParent, headStatus, kids, family); err != nil {
+		return h, err
+	}
+
+	// ? NOTE: Everyone shares the address, coordinates and landline of the head,
+	// ? and the family its ethnicity and religion
+	b.fillAddress(&h.Members[0].Location, pl)
+	id := b.drawIdentity(pl.region)
+	for i := range h.Members {
+		m := &h.Members[i]
+		m.Location = h.Members[0].Location
+		m.Landline = landline
+		if m.DOB.Age >= minAge {
+			if err := b.fillContacts(&m.Pinoy, pl.city, landline); err != nil {
+				return h, err
+			}
+			b.fillEducation(&m.Pinoy, pl)
+			b.fillEmployment(&m.Pinoy, pl.city)
+			b.fillFinance(&m.Pinoy)
+			b.fillHealth(&m.Pinoy)
+			b.fillSocial(&m.Pinoy)
+			b.fillDocuments(&m.Pinoy)
+		}
+		b.fillIdentity(&m.Pinoy, id, pl.region)
+		b.translate(&m.Pinoy)
+		b.selectFields(&m.Pinoy)
+		m.Relationship = b.opts.Lang.relationship(m.Relationship)
+	}
+
+	return h, nil
+}
+
+// addChildren appends the head's children, eldest first. Each is at least
+// 18 years younger than the youngest parent, and a never-married head has
+// children less often.
+func (b *batch) addChildren(
+	h *Household,
+	youngestParent int,
+	headStatus CivilStatus,
+	kids lineage,
+	family uniqueSet,
+) error {
+	rng := b.rng
+
+	n := pickWeighted(childrenWeights, func(w childWeight) int { return w.weight }, rng).children
+	if headStatus == CivilSingle && rng.IntN(100) >= singleParentPercent {
+		n = 0
+	}
+
+	oldest := youngestParent - minAge
+	if oldest < 0 {
+		return nil
+	}
+
+	ages := make([]int, n)
+	for i := range ages {
+		ages[i] = rng.IntN(oldest + 1)
+	}
+	slices.SortFunc(ages, func(a, c int) int { return cmp.Compare(c, a) })
+
+	for _, age := range ages {
+		h.Members = append(h.Members, HouseholdMember{})
+		member := &h.Members[len(h.Members)-1]
+		child := &member.Pinoy
+		name := nameSpec{male: rng.IntN(2) == 0, last: kids.last, family: family}
+		if err := b.fillChild(child, generateDOB(age, b.ref, rng), name); err != nil {
+			return err
+		}
+
+		// ? NOTE: Read the gender back; a small dataset may run out of names for one
+		member.Relationship = RelationshipDaughter
+		if child.Gender == "male" {
+			member.Relationship = RelationshipSon
+		}
+
+		if kids.middle != "" || kids.noMiddle {
+			child.Name.Middle = kids.middle
+		}
+	}
+
+	return nil
+}
+
+// fillChild fills a child like fillPerson; minors get no title.
+func (b *batch) fillChild(p *Pinoy, dob time.Time, name nameSpec) error {
+	if err := b.fillPerson(p, dob, CivilSingle, name); err != nil {
+		return err
+	}
+	if p.DOB.Age < minAge {
+		p.Name.Title = ""
+	}
+	return nil
+}
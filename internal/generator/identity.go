@@ -0,0 +1,43 @@
+package generator
+
+import "github.com/mrjxtr/rpug/internal/data"
+
+// identity is the part of who someone is that a family shares: an
+// ethnolinguistic group and a religion.
+type identity struct {
+	ethnicity data.Ethnicity
+	religion  string
+}
+
+// drawIdentity picks an ethnicity in region, then a religion: the
+// ethnicity's own if it has one, else one from the region. Regions without
+// ethnicities draw nothing.
+func (b *batch) drawIdentity(region data.Location) identity {
+	if len(region.Ethnicities) == 0 {
+		return identity{}
+	}
+
+	id := identity{ethnicity: pickWeighted(region.Ethnicities, func(e data.Ethnicity) int { return e.Weight }, b.rng)}
+	id.religion = id.ethnicity.Religion
+	if id.religion == "" && len(region.Religions) > 0 {
+		id.religion = pickWeighted(region.Religions, func(r data.Religion) int { return r.Weight }, b.rng).Name
+	}
+	return id
+}
+
+// fillIdentity sets the religion, ethnicity and languages of p: the mother
+// tongue first, then each other language of the region by its Percent.
+func (b *batch) fillIdentity(p *Pinoy, id identity, region data.Location) {
+	if id.ethnicity.Name == "" {
+		return
+	}
+
+	p.Religion = id.religion
+	p.Ethnicity = id.ethnicity.Name
+	p.Languages = []string{id.ethnicity.Language}
+	for _, l := range region.Languages {
+		if l.Name != id.ethnicity.Language && b.rng.IntN(100) < l.Percent {
+			p.Languages = append(p.Languages, l.Name)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mrjxtr/rpug/internal/data"
+)
+
+// Lang selects the language of titles, gender labels and formatted fields.
+// Names and places are the same in every language, so a seed draws the same
+// people whatever the language.
+type Lang string
+
+const (
+	LangEnglish  Lang = "en"
+	LangFilipino Lang = "fil"
+)
+
+// Langs lists every supported Lang, in the order the UI offers them.
+var Langs = []Lang{LangEnglish, LangFilipino}
+
+// ncrRegion is the region formatted as Metro Manila.
+const ncrRegion = "National Capital Region"
+
+var (
+	filipinoTitles = map[string]string{
+		"Mr":  "G.",   // Ginoo
+		"Ms":  "Bb.",  // Binibini
+		"Mrs": "Gng.", // Ginang
+	}
+	filipinoGenders = map[string]string{
+		"male":   "lalaki",
+		"female": "babae",
+	}
+	filipinoCivilStatuses = map[CivilStatus]CivilStatus{
+		CivilSingle:    "walang asawa",
+		CivilMarried:   "may asawa",
+		CivilWidowed:   "balo",
+		CivilSeparated: "hiwalay",
+		CivilAnnulled:  "napawalang-bisa ang kasal",
+	}
+	filipinoRelationships = map[Relationship]Relationship{
+		RelationshipHead:     "puno ng sambahayan",
+		RelationshipSpouse:   "asawa",
+		RelationshipSon:      "anak na lalaki",
+		RelationshipDaughter: "anak na babae",
+	}
+	filipinoAttainments = map[Attainment]Attainment{
+		AttainmentElementary: "elementarya",
+		AttainmentJuniorHigh: "junior high school",
+		AttainmentHighSchool: "hayskul",
+		AttainmentCollege:    "kolehiyo",
+	}
+	filipinoMonths = [...]string{
+		"Enero", "Pebrero", "Marso", "Abril", "Mayo", "Hunyo",
+		"Hulyo", "Agosto", "Setyembre", "Oktubre", "Nobyembre", "Disyembre",
+	}
+)
+
+// title translates an English title from the dataset.
+// Titles without a translation are kept as they are.
+func (l Lang) title(t string) string {
+	if l == LangFilipino {
+		if fil, ok := filipinoTitles[t]; ok {
+			return fil
+		}
+	}
+	return t
+}
+
+// gender translates the "male"/"female" label.
+func (l Lang) gender(g string) string {
+	if l == LangFilipino {
+		return filipinoGenders[g]
+	}
+	return g
+}
+
+// civilStatus translates the civil status label.
+func (l Lang) civilStatus(s CivilStatus) CivilStatus {
+	if l == LangFilipino {
+		return filipinoCivilStatuses[s]
+	}
+	return s
+}
+
+// relationship translates a household relationship label.
+func (l Lang) relationship(r Relationship) Relationship {
+	if l == LangFilipino {
+		return filipinoRelationships[r]
+	}
+	return r
+}
+
+// attainment translates an educational attainment label.
+// An empty attainment, for someone without an Education block, stays empty.
+func (l Lang) attainment(a Attainment) Attainment {
+	if l == LangFilipino && a != "" {
+		return filipinoAttainments[a]
+	}
+	return a
+}
+
+func (l Lang) country() string {
+	if l == LangFilipino {
+		return "Pilipinas"
+	}
+	return "Philippines"
+}
+
+// formatDate writes t as a long date, e.g. "March 5, 1994" or "Marso 5, 1994".
+func (l Lang) formatDate(t time.Time) string {
+	if l == LangFilipino {
+		return filipinoMonths[t.Month()-1] + t.Format(" 2, 2006")
+	}
+	return t.Format("January 2, 2006")
+}
+
+// formatAddress writes a one-line address the way it is written locally,
+// e.g. "Brgy. Lahug, Cebu City, Cebu 6000, Philippines" or
+// "Brgy. Lahug, Lungsod ng Cebu, Cebu 6000, Pilipinas".
+// NCR districts are written as Metro Manila, as they are on envelopes.
+func (l Lang) formatAddress(
+	region data.Location,
+	province data.Province,
+	city data.City,
+	barangay data.Barangay,
+) string {
+	brgy := barangay.Name
+	if !strings.HasPrefix(brgy, "Barangay ") {
+		brgy = "Brgy. " + brgy
+	}
+
+	// ? NOTE: Drop the "(La Union)" style disambiguator, the province follows anyway
+	cityName, _, _ := strings.Cut(city.Name, " (")
+	prov := province.Name
+	if region.Region == ncrRegion {
+		prov = "Metro Manila"
+	}
+
+	if l == LangFilipino {
+		if city.Kind == data.KindMunicipality {
+			cityName = "Bayan ng " + cityName
+		} else {
+			cityName = "Lungsod ng " + strings.TrimSuffix(cityName, " City")
+		}
+		if region.Region == ncrRegion {
+			prov = "Kalakhang Maynila"
+		}
+	}
+
+	return brgy + ", " + cityName + ", " + prov + " " + city.Zipcode + ", " + l.country()
+}
@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"encoding/binary"
+	"fmt"
+	mathrand "math/rand/v2"
+	"time"
+)
+
+const (
+	passwordLength  = 10
+	passwordCharset = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+// generateUUID returns a random (version 4) UUID drawn from rng.
+func generateUUID(rng *mathrand.Rand) string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], rng.Uint64())
+	binary.BigEndian.PutUint64(b[8:], rng.Uint64())
+
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// generateUsername builds a handle from the name using the email patterns.
+// When seen is non-nil, a counter is appended until the handle is unused.
+func generateUsername(first, last string, dob time.Time, seen uniqueSet, rng *mathrand.Rand) string {
+	pattern := emailPatterns[rng.IntN(len(emailPatterns))]
+	f, l := slugNames(first, last)
+	base := pattern(f, l, dob, rng)
+
+	if seen == nil {
+		return base
+	}
+
+	return seen.claimNumbered(func(suffix string) string { return base + suffix })
+}
+
+// generatePassword returns a random password without look-alike characters.
+func generatePassword(rng *mathrand.Rand) string {
+	b := make([]byte, passwordLength)
+	for i := range b {
+		b[i] = passwordCharset[rng.IntN(len(passwordCharset))]
+	}
+	return string(b)
+}
@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"fmt"
+	mathrand "math/rand/v2"
+
+	"github.com/mrjxtr/rpug/internal/data"
+)
+
+// nameSpec describes the name drawName should draw.
+type nameSpec struct {
+	male bool
+	// wed marks a woman using her husband's surname: Last is then his
+	// surname, Middle her maiden name, and the title comes from Titles.Married.
+	wed bool
+	// last, when set, is used as the surname instead of a random one.
+	last string
+	// family, when non-nil, holds first names already taken in the
+	// household; the drawn first name is added to it.
+	family uniqueSet
+	// legacy skips the middle name, as dataset versions from before
+	// civilStatusVersion did.
+	legacy bool
+}
+
+// drawName fills the gender, title, first, middle and last name of p.
+// Everyone follows PH convention, with their mother's maiden name as Middle
+// (or their own maiden name, for a wed woman).
+// When seen is non-nil the full name is redrawn until unused in the batch.
+// If random draws keep colliding, every combination is scanned in order,
+// switching gender only once the requested one has no names left.
+func drawName(p *Pinoy, spec nameSpec, names data.Names, seen uniqueSet, rng *mathrand.Rand) error {
+	free := func(first, last string) bool {
+		if _, taken := spec.family[first]; taken {
+			return false
+		}
+		return seen == nil || seen.claim(first+" "+last)
+	}
+
+	for range maxUniqueAttempts {
+		fillName(p, spec, names, rng)
+		if free(p.Name.First, p.Name.Last) {
+			drawMiddleName(p, spec, names, rng)
+			if spec.family != nil {
+				spec.family.claim(p.Name.First)
+			}
+			return nil
+		}
+	}
+
+	lasts := names.LastNames
+	if spec.last != "" {
+		lasts = []string{spec.last}
+	}
+	for _, m := range []bool{spec.male, !spec.male} {
+		firsts := names.FemaleFirstNames
+		if m {
+			firsts = names.MaleFirstNames
+		}
+		for _, first := range firsts {
+			for _, last := range lasts {
+				if free(first, last) {
+					fillName(p, nameSpec{male: m, wed: spec.wed && !m, last: last}, names, rng)
+					p.Name.First = first
+					drawMiddleName(p, spec, names, rng)
+					if spec.family != nil {
+						spec.family.claim(first)
+					}
+					return nil
+				}
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: ran out of unique names", ErrUniqueExhausted)
+}
+
+// fillName draws a gender-appropriate title, first name and last name.
+// Datasets from before Titles.Married fall back to the female titles.
+func fillName(p *Pinoy, spec nameSpec, names data.Names, rng *mathrand.Rand) {
+	if spec.male {
+		p.Gender = "male"
+		p.Name.Title = names.Titles.Male[rng.IntN(len(names.Titles.Male))]
+		p.Name.First = names.MaleFirstNames[rng.IntN(len(names.MaleFirstNames))]
+	} else {
+		titles := names.Titles.Female
+		if spec.wed && len(names.Titles.Married) > 0 {
+			titles = names.Titles.Married
+		}
+		p.Gender = "female"
+		p.Name.Title = titles[rng.IntN(len(titles))]
+		p.Name.First = names.FemaleFirstNames[rng.IntN(len(names.FemaleFirstNames))]
+	}
+
+	p.Name.Last = spec.last
+	if p.Name.Last == "" {
+		p.Name.Last = names.LastNames[rng.IntN(len(names.LastNames))]
+	}
+}
+
+// drawMiddleName draws a surname other than Last for Middle, unless the
+// dataset has only one or spec is legacy.
+func drawMiddleName(p *Pinoy, spec nameSpec, names data.Names, rng *mathrand.Rand) {
+	if spec.legacy {
+		return
+	}
+	p.Name.Middle = names.LastNames[rng.IntN(len(names.LastNames))]
+	for p.Name.Middle == p.Name.Last && len(names.LastNames) > 1 {
+		p.Name.Middle = names.LastNames[rng.IntN(len(names.LastNames))]
+	}
+}
@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mrjxtr/rpug/internal/data"
+)
+
+// Overseas is where an OFW works abroad and under what contract.
+type Overseas struct {
+	Country string `json:"country"`
+	City    string `json:"city"`
+	// Phone is the foreign mobile number, in E.164 form (e.g. "+971501234567").
+	Phone    string   `json:"phone"`
+	Contract Contract `json:"contract"`
+}
+
+// Contract is the employment contract processed with the DMW (formerly POEA).
+// MonthlySalary is in the host country's Currency; it is the PHP salary of
+// the Employment block at the dataset's rate.
+type Contract struct {
+	// OECNumber is the Overseas Employment Certificate number,
+	// "<deployment year>-<7 digits>".
+	OECNumber      string `json:"oec_number"`
+	MonthlySalary  int    `json:"monthly_salary"`
+	Currency       string `json:"currency"`
+	DurationMonths int    `json:"duration_months"`
+	DeploymentDate Date   `json:"deployment_date"`
+	EndDate        Date   `json:"end_date"`
+}
+
+// fillOverseas deploys p to a weighted destination. The current contract
+// started no earlier than their employment and is still running at ref.
+func (b *batch) fillOverseas(p *Pinoy) {
+	countries := b.d.Overseas.Countries
+	if len(countries) == 0 {
+		return
+	}
+	rng := b.rng
+
+	c := pickWeighted(countries, func(c data.Country) int { return c.Weight }, rng)
+	months := c.ContractMonths[rng.IntN(len(c.ContractMonths))]
+
+	// ? NOTE: Deployed within the contract's length before ref, so it hasn't ended yet
+	earliest := b.ref.AddDate(0, -months, 1)
+	if start := p.Employment.StartDate.Time; start.After(earliest) {
+		earliest = start
+	}
+	deployed := timeBetween(earliest, b.ref, rng).Truncate(24 * time.Hour)
+
+	p.Overseas = Overseas{
+		Country: c.Name,
+		City:    c.Cities[rng.IntN(len(c.Cities))],
+		Phone:   generateForeignMobile(c, rng),
+		Contract: Contract{
+			OECNumber:      fmt.Sprintf("%d-%07d", deployed.Year(), rng.IntN(10_000_000)),
+			MonthlySalary:  int(math.Round(float64(p.Employment.MonthlySalary) / c.PHPRate)),
+			Currency:       c.Currency,
+			DurationMonths: months,
+			DeploymentDate: Date{Time: deployed, Format: b.opts.DateFormat},
+			EndDate:        Date{Time: deployed.AddDate(0, months, 0), Format: b.opts.DateFormat},
+		},
+	}
+}
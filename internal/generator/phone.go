@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	mathrand "math/rand/v2"
+
+	"github.com/mrjxtr/rpug/internal/data"
+)
+
+const (
+	metroManilaAreaCode = "02"
+	landlineLocalMax    = 10_000_000 // 7-digit local number; NCR adds a leading 8
+)
+
+// generateMobile picks a provider prefix and appends a 7-digit suffix.
+func generateMobile(providers []string, rng *mathrand.Rand) string {
+	prefix := providers[rng.IntN(len(providers))]
+	suffix := fmt.Sprintf("%07d", rng.IntN(phoneSuffixMax))
+	return prefix + suffix
+}
+
+// generateForeignMobile writes a mobile number in country c in E.164 form,
+// e.g. "+971501234567".
+func generateForeignMobile(c data.Country, rng *mathrand.Rand) string {
+	prefix := c.MobilePrefixes[rng.IntN(len(c.MobilePrefixes))]
+	subscriber := fmt.Sprintf("%0*d", c.SubscriberDigits, rng.Int64N(int64(math.Pow10(c.SubscriberDigits))))
+	return "+" + c.CallingCode + prefix + subscriber
+}
+
+// generateLandline formats a landline under the city's area code.
+// NCR uses 8-digit local numbers "(02) 8xxx-xxxx"; provinces use 7 digits "(0xx) xxx-xxxx".
+func generateLandline(city data.City, rng *mathrand.Rand) string {
+	local := fmt.Sprintf("%07d", rng.IntN(landlineLocalMax))
+
+	if city.AreaCode == metroManilaAreaCode {
+		return fmt.Sprintf("(%s) 8%s-%s", city.AreaCode, local[:3], local[3:])
+	}
+
+	return fmt.Sprintf("(%s) %s-%s", city.AreaCode, local[:3], local[3:])
+}
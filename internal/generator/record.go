@@ -0,0 +1,225 @@
+package generator
+
+import (
+	"fmt"
+	mathrand "math/rand/v2"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/mrjxtr/rpug/internal/config"
+	"github.com/mrjxtr/rpug/internal/data"
+	"github.com/mrjxtr/rpug/internal/portrait"
+)
+
+// batch is what one Generate call shares across its records: the dataset,
+// options and RNG, plus the sets that keep fields unique within the response.
+// Endpoints build records from its fill steps, in a fixed order per endpoint
+// so a seed always makes the same draws.
+type batch struct {
+	cfg       *config.Config
+	d         *data.Data
+	opts      Options
+	rng       *mathrand.Rand
+	ref       time.Time
+	providers []string
+	emails    *emailGenerator
+
+	// ? NOTE: nil sets mean the field is not held unique
+	names, phones, usernames uniqueSet
+
+	// handles keeps social handles and websites unique, always, ids the
+	// license, passport, plate, registration and TIN numbers, and firms the
+	// registered names of businesses
+	handles, ids, firms uniqueSet
+}
+
+// legacyVersion is the last dataset version drawn the way it was released:
+// the age first and then a birthday up to a year before turning it, and
+// registrations counted forward from ref. Later fixes don't reach it, so
+// its pinned seeds keep giving the same people.
+const legacyVersion = 1
+
+// newBatch prepares a batch for about n records.
+func (g *PinoyGenerator) newBatch(d *data.Data, n int, rng *mathrand.Rand, opts Options) *batch {
+	b := &batch{
+		cfg:  g.cfg,
+		d:    d,
+		opts: opts,
+		rng:  rng,
+		// ? NOTE: Ages and dates are as of January 1 of the dataset's year, so a
+		// ? pinned seed gives the same people whatever the date
+		ref: time.Date(d.RefYear, 1, 1, 0, 0, 0, 0, time.UTC),
+		// ? NOTE: Concat copies; append could write into the shared dataset's backing array
+		providers: slices.Concat(d.MobileProviders.GlobeTM, d.MobileProviders.SmartTntSun, d.MobileProviders.Dito),
+		emails:    newEmailGenerator(d.EmailDomains, opts.EmailDomain, n),
+		handles:   make(uniqueSet, n),
+		ids:       make(uniqueSet, n),
+		firms:     make(uniqueSet, n),
+	}
+
+	if opts.isUnique(UniqueName) {
+		b.names = make(uniqueSet, n)
+	}
+	if opts.isUnique(UniquePhone) {
+		b.phones = make(uniqueSet, n)
+	}
+	if opts.isUnique(UniqueUsername) {
+		b.usernames = make(uniqueSet, n)
+	}
+
+	return b
+}
+
+// place is one address in the location tree.
+type place struct {
+	region   data.Location
+	province data.Province
+	city     data.City
+	barangay data.Barangay
+}
+
+// drawRegion picks a region uniformly.
+func (b *batch) drawRegion() data.Location {
+	return b.d.Locations[b.rng.IntN(len(b.d.Locations))]
+}
+
+// drawPlace walks down the location tree from region: province, then
+// city/municipality, then barangay.
+func (b *batch) drawPlace(region data.Location) place {
+	province := region.Provinces[b.rng.IntN(len(region.Provinces))]
+	city := province.Cities[b.rng.IntN(len(province.Cities))]
+	barangay := city.Barangays[b.rng.IntN(len(city.Barangays))]
+	return place{region, province, city, barangay}
+}
+
+// drawPerson draws an adult of any gender and age, and their civil status,
+// and fills them in with fillPerson.
+func (b *batch) drawPerson(p *Pinoy) error {
+	rng := b.rng
+
+	if b.d.Version <= legacyVersion {
+		// ? NOTE: Keep the old order, gender and name before the DOB, so pinned seeds still match
+		if err := b.fillName(p, nameSpec{male: rng.IntN(2) == 0, legacy: true}); err != nil {
+			return err
+		}
+		age := rng.IntN(maxAge-minAge) + minAge
+		b.fillDOB(p, b.ref.AddDate(-age, -rng.IntN(12), -rng.IntN(28)))
+		// ? NOTE: The drawn age stands even where the DOB makes them a year younger
+		p.DOB.Age = age
+		return nil
+	}
+
+	// ? NOTE: Pick an age, then any birthday that makes them that age on ref
+	// ? Age is computed back from the DOB so the two can never disagree
+	dob := generateDOB(rng.IntN(maxAge-minAge)+minAge, b.ref, rng)
+
+	// ? NOTE: Civil status follows age; most wives then take their husband's surname
+	male := rng.IntN(2) == 0
+	status := generateCivilStatus(ageAt(dob, b.ref), rng)
+	wed := !male && status.wasMarried() && rng.IntN(100) < husbandSurnamePercent
+
+	return b.fillPerson(p, dob, status, nameSpec{male: male, wed: wed})
+}
+
+// fillPerson sets the DOB, civil status, names and picture of p.
+func (b *batch) fillPerson(p *Pinoy, dob time.Time, status CivilStatus, name nameSpec) error {
+	b.fillDOB(p, dob)
+	p.CivilStatus = status
+	return b.fillName(p, name)
+}
+
+// fillDOB sets the DOB and age of p.
+func (b *batch) fillDOB(p *Pinoy, dob time.Time) {
+	p.DOB.Age = ageAt(dob, b.ref)
+	p.DOB.Date = Date{Time: dob, Format: b.opts.DateFormat}
+	p.DOB.Formatted = b.opts.Lang.formatDate(dob)
+}
+
+// fillName draws the title and names of p, then a picture to match.
+func (b *batch) fillName(p *Pinoy, name nameSpec) error {
+	// ? NOTE: Generate the title and names based on gender and civil status
+	// ? Redraw the name while it collides with one already in the batch
+	if err := drawName(p, name, b.d.Names, b.names, b.rng); err != nil {
+		return err
+	}
+
+	// ? NOTE: One portrait number per record, shared by all three sizes
+	portraitN := b.rng.IntN(portrait.Count)
+	p.Picture.Large = portrait.URL(b.cfg.BaseURL, p.Gender, portraitN, portrait.Large)
+	p.Picture.Medium = portrait.URL(b.cfg.BaseURL, p.Gender, portraitN, portrait.Medium)
+	p.Picture.Thumbnail = portrait.URL(b.cfg.BaseURL, p.Gender, portraitN, portrait.Thumbnail)
+
+	return nil
+}
+
+// fillAddress sets loc to pl.
+func (b *batch) fillAddress(loc *Location, pl place) {
+	loc.Barangay = pl.barangay.Name
+	loc.City = pl.city.Name
+	loc.Province = pl.province.Name
+	loc.Region = pl.region.Region
+	loc.Country = b.opts.Lang.country()
+	loc.Zipcode = pl.city.Zipcode
+	loc.Formatted = b.opts.Lang.formatAddress(pl.region, pl.province, pl.city, pl.barangay)
+
+	// ? NOTE: Scatter coordinates around the city centroid; the whole country is on PHT
+	lat, lon := jitterCoordinates(pl.city, b.rng)
+	loc.Coordinates.Latitude = strconv.FormatFloat(lat, 'f', 4, 64)
+	loc.Coordinates.Longitude = strconv.FormatFloat(lon, 'f', 4, 64)
+	loc.Timezone.Offset = timezoneOffset
+	loc.Timezone.Description = timezoneName
+}
+
+// fillContacts sets the phones, email, login and registration of an adult.
+// An empty landline draws one in city's area code.
+func (b *batch) fillContacts(p *Pinoy, city data.City, landline string) error {
+	dob := p.DOB.Date.Time
+
+	// ? NOTE: Landline follows the city's area code so it matches Location.City
+	p.Cell = generateMobile(b.providers, b.rng)
+	for attempt := 0; b.phones != nil && !b.phones.claim(p.Cell); attempt++ {
+		if attempt == maxUniqueAttempts {
+			return fmt.Errorf("%w: ran out of unique phones", ErrUniqueExhausted)
+		}
+		p.Cell = generateMobile(b.providers, b.rng)
+	}
+	if landline == "" {
+		landline = generateLandline(city, b.rng)
+	}
+	p.Landline = landline
+	p.Phone = p.Cell
+
+	// ? NOTE: Build an email from the name and DOB on a weighted domain
+	// ? Collisions within the batch get a numeric suffix (e.g., "juan.santos2")
+	p.Email = b.emails.generate(p.Name.First, p.Name.Last, dob, b.rng)
+
+	p.Login.UUID = generateUUID(b.rng)
+	p.Login.Username = generateUsername(p.Name.First, p.Name.Last, dob, b.usernames, b.rng)
+	p.Login.Password = generatePassword(b.rng)
+
+	// ? NOTE: Registered some time in the last MaxRegistrationYears, but never
+	// ? before turning 18; Age is the whole years since then
+	var registered time.Time
+	if b.d.Version <= legacyVersion {
+		// ? NOTE: Counted forward from ref as released, so it can land after ref
+		p.Registered.Age = b.rng.IntN(b.opts.MaxRegistrationYears)
+		registered = b.ref.AddDate(p.Registered.Age, -b.rng.IntN(12), -b.rng.IntN(28))
+	} else {
+		registered = generateRegistration(dob, b.ref, b.opts.MaxRegistrationYears, b.rng)
+		p.Registered.Age = ageAt(registered, b.ref)
+	}
+	p.Registered.Date = Date{Time: registered, Format: b.opts.DateFormat}
+	p.Registered.Formatted = b.opts.Lang.formatDate(registered)
+
+	return nil
+}
+
+// translate puts the labels of p in the requested language.
+// It runs last; the draws before it are the same in every language.
+func (b *batch) translate(p *Pinoy) {
+	p.Name.Title = b.opts.Lang.title(p.Name.Title)
+	p.Gender = b.opts.Lang.gender(p.Gender)
+	p.CivilStatus = b.opts.Lang.civilStatus(p.CivilStatus)
+	p.Education.Attainment = b.opts.Lang.attainment(p.Education.Attainment)
+}
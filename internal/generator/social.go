@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"fmt"
+	mathrand "math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/mrjxtr/rpug/internal/data"
+)
+
+// socialTLD is the top-level domain of every profile URL and website,
+// reserved by RFC 2606 so it never resolves.
+const socialTLD = ".example"
+
+const (
+	websiteSeparator = "-"
+	maxLabelLength   = 63 // longest DNS label, for website hosts
+	handleTrim       = "._-"
+)
+
+// Social is an adult's accounts on the social networks in the dataset and,
+// for a few, a personal website.
+type Social struct {
+	Accounts []SocialAccount `json:"accounts,omitempty"`
+	Website  string          `json:"website,omitempty"`
+}
+
+type SocialAccount struct {
+	Platform string `json:"platform"`
+	Handle   string `json:"handle"`
+	URL      string `json:"url"`
+}
+
+// handlePatterns build a handle from a first and last name, joining the
+// parts with sep. Each mirrors a habit seen on PH social media.
+var handlePatterns = []func(first, last, sep string, dob time.Time, rng *mathrand.Rand) string{
+	// maria.clara.delacruz
+	func(first, last, sep string, _ time.Time, _ *mathrand.Rand) string {
+		return nameWords(first, sep) + sep + slugName(last)
+	},
+	// juandc_23
+	func(first, last, sep string, _ time.Time, rng *mathrand.Rand) string {
+		return fmt.Sprintf("%s%s%s%02d", slugName(first), initials(last), sep, rng.IntN(100))
+	},
+	// its.maria.clara
+	func(first, _, sep string, _ time.Time, _ *mathrand.Rand) string {
+		return "its" + sep + nameWords(first, sep)
+	},
+	// iamjuandelacruz
+	func(first, last, _ string, _ time.Time, _ *mathrand.Rand) string {
+		return "iam" + slugName(first) + slugName(last)
+	},
+	// juan_delacruz92
+	func(first, last, sep string, dob time.Time, _ *mathrand.Rand) string {
+		return fmt.Sprintf("%s%s%s%02d", slugName(first), sep, slugName(last), dob.Year()%100)
+	},
+	// delacruz.juan
+	func(first, last, sep string, _ time.Time, _ *mathrand.Rand) string {
+		return slugName(last) + sep + nameWords(first, sep)
+	},
+}
+
+// fillSocial gives an adult an account on each platform they use, with a
+// handle unique to that platform in the batch, and sometimes a website.
+// It runs after fillContacts.
+func (b *batch) fillSocial(p *Pinoy) {
+	soc := b.d.Social
+	if len(soc.Platforms) == 0 {
+		return
+	}
+	rng := b.rng
+	dob := p.DOB.Date.Time
+
+	for _, pl := range soc.Platforms {
+		if rng.IntN(100) >= pl.Percent {
+			continue
+		}
+		sep := ""
+		if pl.Separators != "" {
+			sep = string(pl.Separators[rng.IntN(len(pl.Separators))])
+		}
+		host := platformHost(pl)
+		handle := b.claimHandle(host, generateHandle(p.Name.First, p.Name.Last, sep, dob, rng), pl.MaxLength)
+		p.Social.Accounts = append(p.Social.Accounts, SocialAccount{
+			Platform: pl.Name,
+			Handle:   handle,
+			URL:      "https://" + host + "/" + handle,
+		})
+	}
+
+	if rng.IntN(100) < soc.WebsitePercent {
+		site := b.claimHandle("website", generateHandle(p.Name.First, p.Name.Last, websiteSeparator, dob, rng), maxLabelLength)
+		p.Social.Website = "https://" + site + socialTLD
+	}
+}
+
+// generateHandle builds a handle from the name with a random pattern.
+func generateHandle(first, last, sep string, dob time.Time, rng *mathrand.Rand) string {
+	pattern := handlePatterns[rng.IntN(len(handlePatterns))]
+	return pattern(first, last, sep, dob, rng)
+}
+
+// claimHandle claims base within namespace, cut to maxLength, with a
+// counter appended until it is unused, and returns the claimed handle.
+func (b *batch) claimHandle(namespace, base string, maxLength int) string {
+	key := b.handles.claimNumbered(func(suffix string) string {
+		h := base
+		if n := maxLength - len(suffix); len(h) > n {
+			// ? NOTE: Don't leave a dangling separator before the counter
+			h = strings.TrimRight(h[:n], handleTrim)
+		}
+		return namespace + "/" + h + suffix
+	})
+	_, handle, _ := strings.Cut(key, "/")
+	return handle
+}
+
+// nameWords slugs each word of name and joins them with sep.
+// "Maria Clara" becomes "maria.clara" with sep ".".
+func nameWords(name, sep string) string {
+	var words []string
+	for _, word := range strings.Fields(name) {
+		if s := slugName(word); s != "" {
+			words = append(words, s)
+		}
+	}
+	return strings.Join(words, sep)
+}
+
+// platformHost returns the .example host of a dataset platform.
+func platformHost(pl data.Platform) string {
+	return strings.ToLower(pl.Name) + socialTLD
+}
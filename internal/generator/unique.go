@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"errors"
+	"strconv"
+)
+
+// UniqueField names a Pinoy field that can be held unique across a batch.
+type UniqueField string
+
+const (
+	UniqueEmail    UniqueField = "email"
+	UniquePhone    UniqueField = "phone"
+	UniqueUsername UniqueField = "username"
+	UniqueName     UniqueField = "name"
+)
+
+// UniqueFields lists every field accepted in Options.Unique.
+var UniqueFields = []UniqueField{UniqueEmail, UniquePhone, UniqueUsername, UniqueName}
+
+// ErrUniqueExhausted is returned when the dataset is too small to keep a
+// requested field unique across the whole batch.
+var ErrUniqueExhausted = errors.New("dataset too small for requested uniqueness")
+
+// maxUniqueAttempts caps redraws per record before giving up on a field.
+const maxUniqueAttempts = 1000
+
+// uniqueSet records values already handed out in one batch.
+type uniqueSet map[string]struct{}
+
+// claim marks v as used and reports whether it was still free.
+func (u uniqueSet) claim(v string) bool {
+	if _, taken := u[v]; taken {
+		return false
+	}
+	u[v] = struct{}{}
+	return true
+}
+
+// claimNumbered claims build(""), or else build("2"), build("3"), ... until
+// one is free, and returns the claimed value.
+func (u uniqueSet) claimNumbered(build func(suffix string) string) string {
+	v := build("")
+	for i := 2; !u.claim(v); i++ {
+		v = build(strconv.Itoa(i))
+	}
+	return v
+}
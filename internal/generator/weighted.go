@@ -0,0 +1,24 @@
+package generator
+
+import mathrand "math/rand/v2"
+
+// pickWeighted returns one of items, chosen in proportion to weight(item).
+// Items with a weight of zero or less are never picked.
+func pickWeighted[T any](items []T, weight func(T) int, rng *mathrand.Rand) T {
+	total := 0
+	for _, it := range items {
+		total += max(weight(it), 0)
+	}
+
+	n := rng.IntN(total)
+	for _, it := range items {
+		w := max(weight(it), 0)
+		if n < w {
+			return it
+		}
+		n -= w
+	}
+
+	// unreachable while total > 0
+	return items[len(items)-1]
+}
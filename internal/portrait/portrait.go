@@ -0,0 +1,101 @@
+// Package portrait renders deterministic avatar SVGs for generated Pinoys.
+// Nothing is fetched or stored: the same gender and number always draw the
+// same geometric identicon, so picture URLs stay stable per seed.
+package portrait
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Count is how many distinct portraits exist per gender (0 to Count-1).
+	Count = 100
+
+	// PathPrefix is where the server mounts the portrait route.
+	PathPrefix = "/api/v1/portraits"
+
+	gridSize = 5 // 5x5 cells, mirrored left to right
+)
+
+// Size is a named portrait size, mirroring randomuser.me's picture block.
+type Size string
+
+const (
+	Large     Size = "large"
+	Medium    Size = "medium"
+	Thumbnail Size = "thumbnail"
+)
+
+// Pixels returns the rendered width and height of s, or 0 if s is unknown.
+func (s Size) Pixels() int {
+	switch s {
+	case Large:
+		return 128
+	case Medium:
+		return 72
+	case Thumbnail:
+		return 48
+	default:
+		return 0
+	}
+}
+
+// URL builds the portrait URL under baseURL, e.g.
+// "https://randompinoy.xyz/api/v1/portraits/female/42.svg?size=medium".
+// Large is the default size, so it carries no query string.
+func URL(baseURL, gender string, n int, size Size) string {
+	u := fmt.Sprintf("%s%s/%s/%d.svg", baseURL, PathPrefix, gender, n)
+	if size != Large {
+		u += "?size=" + string(size)
+	}
+	return u
+}
+
+// Valid reports whether gender and n name an existing portrait.
+func Valid(gender string, n int) bool {
+	return (gender == "male" || gender == "female") && n >= 0 && n < Count
+}
+
+// SVG renders portrait n for gender at the given pixel size.
+// Cells are squares for "male" and circles for "female"; the hue and the
+// cell pattern come from a hash of the gender and number.
+func SVG(gender string, n int, px int) []byte {
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s/%d", gender, n))
+	hue := (int(sum[0])<<8 | int(sum[1])) % 360
+
+	var b strings.Builder
+	fmt.Fprintf(&b,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`,
+		px, px, gridSize+2, gridSize+2,
+	)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="hsl(%d,45%%,92%%)"/>`, hue)
+	fmt.Fprintf(&b, `<g fill="hsl(%d,55%%,45%%)">`, hue)
+
+	half := (gridSize + 1) / 2
+	for y := range gridSize {
+		for x := range half {
+			bit := y*half + x
+			if sum[2+bit/8]>>(bit%8)&1 == 0 {
+				continue
+			}
+			writeCell(&b, gender, x, y)
+			if mirror := gridSize - 1 - x; mirror != x {
+				writeCell(&b, gender, mirror, y)
+			}
+		}
+	}
+
+	b.WriteString(`</g></svg>`)
+	return []byte(b.String())
+}
+
+// writeCell draws one grid cell, offset by the 1-cell margin.
+func writeCell(b *strings.Builder, gender string, x, y int) {
+	if gender == "female" {
+		fmt.Fprintf(b, `<circle cx="%d.5" cy="%d.5" r="0.5"/>`, x+1, y+1)
+		return
+	}
+	fmt.Fprintf(b, `<rect x="%d" y="%d" width="1" height="1"/>`, x+1, y+1)
+}
@@ -0,0 +1,24 @@
+package portrait
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestSVGDeterminism ensures a portrait renders identically every time and
+// differs between genders.
+func TestSVGDeterminism(t *testing.T) {
+	a := SVG("female", 42, Medium.Pixels())
+	b := SVG("female", 42, Medium.Pixels())
+	if !bytes.Equal(a, b) {
+		t.Errorf("expected identical SVGs for the same portrait")
+	}
+
+	if bytes.Equal(a, SVG("male", 42, Medium.Pixels())) {
+		t.Errorf("expected different SVGs for different genders")
+	}
+
+	if !bytes.Contains(a, []byte(`width="72"`)) {
+		t.Errorf("expected medium portrait to be 72px wide, got: %s", a)
+	}
+}
@@ -1,10 +1,71 @@
 package server
 
-import "net/http"
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
 
-// handlePinoysAPI parses ?seed= and ?results= from the request, generates a
-// deterministic PinoyResponse, and writes it as JSON.
+	"github.com/go-chi/chi/v5"
+	"github.com/mrjxtr/rpug/internal/generator"
+	"github.com/mrjxtr/rpug/internal/portrait"
+)
+
+const portraitCacheControl = "public, max-age=31536000, immutable" // same URL, same image
+
+// handlePinoysAPI parses ?seed=, ?results= and the generator options from the
+// request, generates a deterministic PinoyResponse, and writes it as JSON.
 func (s *Server) handlePinoysAPI(w http.ResponseWriter, r *http.Request) {
+	results, seed, opts, ok := s.getGenerateParams(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := s.gen.Generate(results, seed, opts)
+	if err != nil {
+		respondWithGenerateError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, resp)
+}
+
+// handleHouseholdsAPI is handlePinoysAPI for households: ?results= counts
+// households, not people.
+func (s *Server) handleHouseholdsAPI(w http.ResponseWriter, r *http.Request) {
+	results, seed, opts, ok := s.getGenerateParams(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := s.gen.GenerateHouseholds(results, seed, opts)
+	if err != nil {
+		respondWithGenerateError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, resp)
+}
+
+// handleBusinessesAPI is handlePinoysAPI for businesses, each with an owner.
+func (s *Server) handleBusinessesAPI(w http.ResponseWriter, r *http.Request) {
+	results, seed, opts, ok := s.getGenerateParams(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := s.gen.GenerateBusinesses(results, seed, opts)
+	if err != nil {
+		respondWithGenerateError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, resp)
+}
+
+// getGenerateParams parses the parameters every generating endpoint takes.
+// On a bad parameter it writes the 400 itself and returns ok false.
+func (s *Server) getGenerateParams(w http.ResponseWriter, r *http.Request) (int, string, generator.Options, bool) {
 	results, err := s.getResultsParam(r)
 	if err != nil {
 		respondWithError(
@@ -12,19 +73,63 @@ func (s *Server) handlePinoysAPI(w http.ResponseWriter, r *http.Request) {
 			http.StatusBadRequest,
 			"invalid 'results' query parameter",
 		)
-		return
+		return 0, "", generator.Options{}, false
 	}
 	seed := getSeedParam(r)
-
-	resp, err := s.gen.Generate(results, seed)
+	opts, err := getOptionsParams(r)
 	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return 0, "", generator.Options{}, false
+	}
+
+	return results, seed, opts, true
+}
+
+// respondWithGenerateError maps a generator error to its status code.
+// Client mistakes keep their message; anything else is a bare 500.
+func respondWithGenerateError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, generator.ErrUniqueExhausted):
+		respondWithError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, generator.ErrUnknownDataVersion),
+		errors.Is(err, generator.ErrNoHouseholds),
+		errors.Is(err, generator.ErrNoBusinesses):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	default:
+		slog.Error("generate failed", "error", err)
 		respondWithError(
 			w,
 			http.StatusInternalServerError,
 			http.StatusText(http.StatusInternalServerError),
 		)
+	}
+}
+
+// handlePortrait renders /portraits/{gender}/{n}.svg as an identicon SVG.
+// ?size= picks large (default), medium or thumbnail. Output never changes for
+// a given URL, so it is cached aggressively.
+func (s *Server) handlePortrait(w http.ResponseWriter, r *http.Request) {
+	gender := chi.URLParam(r, "gender")
+	n, err := strconv.Atoi(chi.URLParam(r, "n"))
+	if err != nil || !portrait.Valid(gender, n) {
+		respondWithError(w, http.StatusNotFound, http.StatusText(http.StatusNotFound))
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, resp)
+	size := portrait.Large
+	if q := r.URL.Query().Get("size"); q != "" {
+		size = portrait.Size(q)
+	}
+	px := size.Pixels()
+	if px == 0 {
+		respondWithError(w, http.StatusBadRequest, "invalid 'size' query parameter")
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", portraitCacheControl)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(portrait.SVG(gender, n, px)); err != nil {
+		slog.Error("Error writing portrait", "error", err)
+	}
 }
@@ -20,7 +20,9 @@ const (
 
 // Generator is the interface for generating Pinoy data.
 type Generator interface {
-	Generate(results int, seed string) (*generator.PinoyResponse, error)
+	Generate(results int, seed string, opts generator.Options) (*generator.PinoyResponse, error)
+	GenerateHouseholds(results int, seed string, opts generator.Options) (*generator.HouseholdResponse, error)
+	GenerateBusinesses(results int, seed string, opts generator.Options) (*generator.BusinessResponse, error)
 }
 
 type Server struct {
@@ -60,8 +62,18 @@ func (s *Server) SetupRouter() *chi.Mux {
 	})
 
 	r.Route("/api/v1", func(r chi.Router) {
-		r.Use(httprate.LimitByRealIP(rateLimitPerMinute, time.Minute))
-		r.Get("/pinoys", s.handlePinoysAPI)
+		r.Group(func(r chi.Router) {
+			r.Use(httprate.LimitByRealIP(rateLimitPerMinute, time.Minute))
+			r.Get("/pinoys", s.handlePinoysAPI)
+			r.Get("/households", s.handleHouseholdsAPI)
+			r.Get("/businesses", s.handleBusinessesAPI)
+		})
+
+		// Portraits load in bulk alongside result pages, so they share the views limit.
+		r.Group(func(r chi.Router) {
+			r.Use(httprate.LimitByRealIP(viewsRateLimitPerMinute, time.Minute))
+			r.Get("/portraits/{gender}/{n}.svg", s.handlePortrait)
+		})
 	})
 
 	return r
@@ -2,9 +2,26 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+
+	"github.com/mrjxtr/rpug/internal/generator"
+)
+
+// maxRegistrationYears caps ?max_registration_years=; the oldest Pinoy is
+// barely older than 18 plus this anyway.
+const maxRegistrationYears = 50
+
+// emailDomainPattern matches a lowercase hostname with at least one dot,
+// e.g. "example.test" or "mail.example.com".
+var emailDomainPattern = regexp.MustCompile(
+	`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)+$`,
 )
 
 // respondWithJSON encodes payload as JSON and writes it with the given status code.
@@ -63,3 +80,101 @@ func getSeedParam(r *http.Request) string {
 	}
 	return ""
 }
+
+// getOptionsParams parses the generator tuning parameters (?email_domain=,
+// ?unique=, ?data_version=, ?lang=, ?date_format=, ?max_registration_years=,
+// ?ofw_ratio=, ?inc=, ?exc=)
+// from the request. The returned error is safe to show to the client.
+func getOptionsParams(r *http.Request) (generator.Options, error) {
+	var opts generator.Options
+	query := r.URL.Query()
+
+	domain := strings.ToLower(query.Get("email_domain"))
+	if domain != "" && !emailDomainPattern.MatchString(domain) {
+		return opts, errors.New("invalid 'email_domain' query parameter")
+	}
+	opts.EmailDomain = domain
+
+	if unique := query.Get("unique"); unique != "" {
+		for field := range strings.SplitSeq(unique, ",") {
+			f := generator.UniqueField(strings.TrimSpace(field))
+			if !slices.Contains(generator.UniqueFields, f) {
+				return opts, fmt.Errorf("invalid 'unique' query parameter: unknown field %q", field)
+			}
+			opts.Unique = append(opts.Unique, f)
+		}
+	}
+
+	include, err := getFieldsParam(query.Get("inc"))
+	if err != nil {
+		return opts, fmt.Errorf("invalid 'inc' query parameter: %w", err)
+	}
+	opts.Include = include
+
+	exclude, err := getFieldsParam(query.Get("exc"))
+	if err != nil {
+		return opts, fmt.Errorf("invalid 'exc' query parameter: %w", err)
+	}
+	opts.Exclude = exclude
+
+	if lang := generator.Lang(query.Get("lang")); lang != "" {
+		if !slices.Contains(generator.Langs, lang) {
+			return opts, errors.New("invalid 'lang' query parameter")
+		}
+		opts.Lang = lang
+	}
+
+	if format := generator.DateFormat(query.Get("date_format")); format != "" {
+		if !slices.Contains(generator.DateFormats, format) {
+			return opts, errors.New("invalid 'date_format' query parameter")
+		}
+		opts.DateFormat = format
+	}
+
+	if years := query.Get("max_registration_years"); years != "" {
+		y, err := strconv.Atoi(years)
+		if err != nil || y < 1 || y > maxRegistrationYears {
+			return opts, fmt.Errorf(
+				"invalid 'max_registration_years' query parameter: must be 1-%d",
+				maxRegistrationYears,
+			)
+		}
+		opts.MaxRegistrationYears = y
+	}
+
+	if ratio := query.Get("ofw_ratio"); ratio != "" {
+		f, err := strconv.ParseFloat(ratio, 64)
+		if err != nil || !(f >= 0 && f <= 1) {
+			return opts, errors.New("invalid 'ofw_ratio' query parameter: must be 0-1")
+		}
+		opts.OFWRatio = &f
+	}
+
+	if version := query.Get("data_version"); version != "" {
+		v, err := strconv.Atoi(version)
+		if err != nil || v < 1 {
+			return opts, errors.New("invalid 'data_version' query parameter")
+		}
+		opts.DataVersion = v
+	}
+
+	return opts, nil
+}
+
+// getFieldsParam parses a comma-separated list of response fields, as in
+// ?inc=name,dob or ?exc=health.
+func getFieldsParam(list string) ([]generator.Field, error) {
+	if list == "" {
+		return nil, nil
+	}
+
+	var fields []generator.Field
+	for field := range strings.SplitSeq(list, ",") {
+		f := generator.Field(strings.TrimSpace(field))
+		if !slices.Contains(generator.Fields, f) {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
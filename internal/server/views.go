@@ -8,28 +8,17 @@ import (
 	"github.com/mrjxtr/rpug/internal/views/pages"
 )
 
-// handlePinoysPage parses ?seed= and ?results= from the request, generates a
-// deterministic PinoyResponse, and renders the playground page.
+// handlePinoysPage parses ?seed=, ?results= and the generator options from the
+// request, generates a deterministic PinoyResponse, and renders the playground page.
 func (s *Server) handlePinoysPage(w http.ResponseWriter, r *http.Request) {
-	results, err := s.getResultsParam(r)
-	if err != nil {
-		respondWithError(
-			w,
-			http.StatusBadRequest,
-			"invalid 'results' query parameter",
-		)
+	results, seed, opts, ok := s.getGenerateParams(w, r)
+	if !ok {
 		return
 	}
-	seed := getSeedParam(r)
 
-	resp, err := s.gen.Generate(results, seed)
+	resp, err := s.gen.Generate(results, seed, opts)
 	if err != nil {
-		slog.Error("generate failed", "error", err)
-		http.Error(
-			w,
-			http.StatusText(http.StatusInternalServerError),
-			http.StatusInternalServerError,
-		)
+		respondWithGenerateError(w, err)
 		return
 	}
 
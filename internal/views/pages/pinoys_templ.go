@@ -42,7 +42,7 @@ func PinoysPage(resp *generator.PinoyResponse, max int) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = components.Form(resp.Info.Results, max).Render(ctx, templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = components.Form(resp.Info.Results, max, resp.Info.Lang).Render(ctx, templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
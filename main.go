@@ -3,7 +3,6 @@ package main
 import (
 	"context"
 	"embed"
-	"encoding/json"
 	"log/slog"
 	"net/http"
 	"os"
@@ -12,7 +11,6 @@ import (
 	"time"
 
 	"github.com/mrjxtr/rpug/internal/config"
-	"github.com/mrjxtr/rpug/internal/data"
 	"github.com/mrjxtr/rpug/internal/generator"
 	"github.com/mrjxtr/rpug/internal/server"
 )
@@ -28,10 +26,17 @@ const (
 //go:embed data/data.json
 var dataJSON []byte
 
+//go:embed data/versions
+var versionsFS embed.FS
+
 //go:embed all:static
 var sfs embed.FS
 
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1:]))
+	}
+
 	slog.Info("Loading configs...")
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -40,14 +45,24 @@ func main() {
 	}
 
 	slog.Info("Loading data...")
-	var d data.Data
-	if err := json.Unmarshal(dataJSON, &d); err != nil {
+	d, files, err := loadData(cfg)
+	if err != nil {
 		slog.Error("Error loading data", "error", err)
 		os.Exit(1)
 	}
+	for _, f := range files {
+		slog.Info("Applied external dataset", "file", f, "mode", cfg.DataMode)
+	}
+
+	frozen, err := loadVersions(d.Version)
+	if err != nil {
+		slog.Error("Error loading frozen data versions", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("Loaded data", "version", d.Version, "frozen_versions", len(frozen), "data_hash", d.Hash)
 
 	slog.Info("Loading generators...")
-	gen := generator.NewPinoyGenerator(cfg, &d)
+	gen := generator.NewPinoyGenerator(cfg, d, frozen...)
 
 	slog.Info("Loading server, middleware, and routes...")
 	srv := server.NewServer(gen, cfg, sfs)
@@ -80,11 +95,22 @@ func main() {
 		}
 	}()
 
+	stopHangup := reloadOnHangup(cfg, gen)
+
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	if cfg.DataWatch {
+		slog.Info("Watching external datasets for changes", "interval", dataWatchInterval)
+		go watchData(watchCtx, cfg, func() { reloadData(cfg, gen, "file change") })
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
 	<-quit
 	slog.Info("Shutting down server")
+	stopWatch()
+	stopHangup()
 
 	shutdownCtx, shutdownCancel := context.WithTimeout(
 		context.Background(),
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mrjxtr/rpug/internal/config"
+	"github.com/mrjxtr/rpug/internal/data"
+	"github.com/mrjxtr/rpug/internal/generator"
+)
+
+// dataWatchInterval is how often watchData polls; tests shorten it.
+var dataWatchInterval = 2 * time.Second
+
+// loadData reads the embedded dataset with the configured external files
+// layered on top, and validates the result.
+func loadData(cfg *config.Config) (*data.Data, []string, error) {
+	files, err := data.Files(cfg.DataPath, cfg.DataDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := data.Load(dataJSON, files, cfg.DataMode == config.DataModeReplace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := d.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid data, run `rpug data lint` for details: %w", err)
+	}
+
+	return d, files, nil
+}
+
+// loadVersions reads the frozen datasets embedded from data/versions.
+// They never change at runtime, so reloads leave them alone.
+func loadVersions(latest int) ([]*data.Data, error) {
+	sub, err := fs.Sub(versionsFS, "data/versions")
+	if err != nil {
+		return nil, err
+	}
+	return data.LoadVersions(sub, latest)
+}
+
+// reloadData swaps a freshly loaded dataset into gen.
+// If loading fails the current dataset keeps serving.
+func reloadData(cfg *config.Config, gen *generator.PinoyGenerator, reason string) {
+	d, _, err := loadData(cfg)
+	if err != nil {
+		slog.Error("Data reload failed, keeping current data", "reason", reason, "error", err)
+		return
+	}
+
+	gen.SetData(d)
+	slog.Info("Data reloaded", "reason", reason, "data_hash", d.Hash)
+}
+
+// reloadOnHangup reloads the dataset into gen on every SIGHUP until the
+// returned stop function is called.
+func reloadOnHangup(cfg *config.Config, gen *generator.PinoyGenerator) (stop func()) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			reloadData(cfg, gen, "SIGHUP")
+		}
+	}()
+
+	return func() {
+		// ? NOTE: No signal is delivered after Stop returns, so closing is safe
+		signal.Stop(hup)
+		close(hup)
+	}
+}
+
+// watchData polls the external dataset files and calls onChange whenever
+// one is added, removed or modified, until ctx is done.
+// Polling keeps us free of platform-specific watchers; datasets are small.
+func watchData(ctx context.Context, cfg *config.Config, onChange func()) {
+	last := dataFilesState(cfg)
+
+	ticker := time.NewTicker(dataWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if state := dataFilesState(cfg); state != last {
+				last = state
+				onChange()
+			}
+		}
+	}
+}
+
+// dataFilesState summarises the external files' names, sizes and
+// modification times, so any edit changes the result.
+func dataFilesState(cfg *config.Config) string {
+	files, err := data.Files(cfg.DataPath, cfg.DataDir)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+
+	var b strings.Builder
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			fmt.Fprintf(&b, "%s missing\n", f)
+			continue
+		}
+		fmt.Fprintf(&b, "%s %d %d\n", f, info.Size(), info.ModTime().UnixNano())
+	}
+	return b.String()
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/mrjxtr/rpug/internal/config"
+	"github.com/mrjxtr/rpug/internal/generator"
+)
+
+// TestReloadData checks that a reload swaps in the edited dataset, and that
+// a broken or invalid file keeps the current one serving.
+func TestReloadData(t *testing.T) {
+	cfg, path := reloadConfig(t)
+	gen := reloadGenerator(t, cfg)
+	before := dataHash(t, gen)
+
+	writeData(t, path, `{ "names": { "last_names": ["Dimaculangan"] } }`)
+	reloadData(cfg, gen, "test")
+	after := dataHash(t, gen)
+	if after == before {
+		t.Fatalf("expected data hash to change after reload, still %s", after)
+	}
+
+	for _, bad := range []string{
+		`{ "names": `,
+		`{ "names": { "last_names": ["李"] } }`,
+	} {
+		writeData(t, path, bad)
+		reloadData(cfg, gen, "test")
+		if got := dataHash(t, gen); got != after {
+			t.Errorf("expected %q to keep data hash %s, got %s", bad, after, got)
+		}
+	}
+}
+
+// TestReloadConcurrent swaps datasets while requests run, so `go test -race`
+// catches unsynchronised access and no request sees a missing dataset.
+func TestReloadConcurrent(t *testing.T) {
+	cfg, path := reloadConfig(t)
+	gen := reloadGenerator(t, cfg)
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				resp, err := gen.Generate(5, "", generator.Options{})
+				if err != nil {
+					t.Errorf("expected no error, got: %v", err)
+					return
+				}
+				if resp.Results == nil || len(*resp.Results) != 5 || resp.Info.DataHash == "" {
+					t.Errorf("expected 5 results with a data hash, got %+v", resp.Info)
+					return
+				}
+			}
+		})
+	}
+
+	files := []string{
+		`{ "names": { "last_names": ["Dimaculangan"] } }`,
+		`{ "names": `,
+		`{ "names": { "last_names": ["Macaraeg"] } }`,
+		`{ "names": { "last_names": ["李"] } }`,
+	}
+	for i := range 20 {
+		writeData(t, path, files[i%len(files)])
+		reloadData(cfg, gen, "test")
+	}
+	close(done)
+	wg.Wait()
+}
+
+// TestReloadOnHangup checks that a SIGHUP reloads the dataset.
+func TestReloadOnHangup(t *testing.T) {
+	cfg, path := reloadConfig(t)
+	gen := reloadGenerator(t, cfg)
+	before := dataHash(t, gen)
+
+	stop := reloadOnHangup(cfg, gen)
+	defer stop()
+
+	writeData(t, path, `{ "names": { "last_names": ["Dimaculangan"] } }`)
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := p.Signal(syscall.SIGHUP); err != nil {
+		t.Skipf("cannot send SIGHUP on this platform: %v", err)
+	}
+
+	waitFor(t, func() bool { return dataHash(t, gen) != before })
+}
+
+// TestWatchData checks that the watcher notices an edited file and stops
+// once its context is done.
+func TestWatchData(t *testing.T) {
+	interval := dataWatchInterval
+	dataWatchInterval = 10 * time.Millisecond
+	defer func() { dataWatchInterval = interval }()
+
+	cfg, path := reloadConfig(t)
+	changed := make(chan struct{}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		watchData(ctx, cfg, func() {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		})
+		close(stopped)
+	}()
+
+	// The watcher takes its baseline once it starts, which may be after an
+	// edit, so keep growing the file until it reports one. A different size
+	// changes the state even if the mtime doesn't move.
+	names := `"Dimaculangan"`
+	deadline := time.After(5 * time.Second)
+	for reported := false; !reported; {
+		names += `, "Macaraeg"`
+		writeData(t, path, `{ "names": { "last_names": [`+names+`] } }`)
+		select {
+		case <-changed:
+			reported = true
+		case <-time.After(5 * dataWatchInterval):
+		case <-deadline:
+			t.Fatal("expected watcher to report the edited file")
+		}
+	}
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected watcher to stop after cancel")
+	}
+}
+
+// reloadConfig points DataPath at a fresh merge file in a temp dir.
+func reloadConfig(t *testing.T) (*config.Config, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "extra.json")
+	writeData(t, path, `{}`)
+	return &config.Config{DataPath: path, DataMode: config.DataModeMerge}, path
+}
+
+func reloadGenerator(t *testing.T, cfg *config.Config) *generator.PinoyGenerator {
+	t.Helper()
+	d, _, err := loadData(cfg)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	return generator.NewPinoyGenerator(cfg, d)
+}
+
+func writeData(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+}
+
+// dataHash returns the hash of the dataset gen is serving.
+func dataHash(t *testing.T, gen *generator.PinoyGenerator) string {
+	t.Helper()
+	resp, err := gen.Generate(1, "", generator.Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	return resp.Info.DataHash
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for reload")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}